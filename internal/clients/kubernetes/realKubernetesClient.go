@@ -24,3 +24,7 @@ func (r *RealKubernetesClient) ListPods(ctx context.Context, namespace string, o
 func (r *RealKubernetesClient) ListNamespaces(ctx context.Context, opts metav1.ListOptions) (*corev1.NamespaceList, error) {
 	return r.ClientSet.CoreV1().Namespaces().List(ctx, opts)
 }
+
+func (r *RealKubernetesClient) GetNamespace(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Namespace, error) {
+	return r.ClientSet.CoreV1().Namespaces().Get(ctx, name, opts)
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"log"
+	"net/http"
+	"strings"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+)
+
+type IClientAuthHandler interface {
+	Wrap(next http.HandlerFunc) http.HandlerFunc
+}
+
+// ClientAuthHandler only lets requests through whose client certificate was verified during the
+// handshake and, when an allow-list is configured, whose common name is on it.
+type ClientAuthHandler struct {
+	allowedCommonNames map[string]struct{}
+}
+
+func NewClientAuthHandler(config *ultron.Config) *ClientAuthHandler {
+	if config == nil {
+		config = &ultron.Config{}
+	}
+
+	allowedCommonNames := make(map[string]struct{})
+	for _, commonName := range strings.Split(config.ClientAllowedCommonNamesCSV, ",") {
+		if commonName = strings.TrimSpace(commonName); commonName != "" {
+			allowedCommonNames[commonName] = struct{}{}
+		}
+	}
+
+	return &ClientAuthHandler{
+		allowedCommonNames: allowedCommonNames,
+	}
+}
+
+func (ch *ClientAuthHandler) Wrap(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 || len(r.TLS.VerifiedChains[0]) == 0 {
+			log.Printf("Could not authenticate client %s: no verified client certificate", r.RemoteAddr)
+			http.Error(w, "client certificate required", http.StatusUnauthorized)
+
+			return
+		}
+
+		commonName := r.TLS.VerifiedChains[0][0].Subject.CommonName
+
+		if len(ch.allowedCommonNames) > 0 {
+			if _, allowed := ch.allowedCommonNames[commonName]; !allowed {
+				log.Printf("Could not authorize client %s: common name %q is not allowed", r.RemoteAddr, commonName)
+				http.Error(w, "client not allowed", http.StatusForbidden)
+
+				return
+			}
+		}
+
+		next(w, r)
+	}
+}
@@ -0,0 +1,76 @@
+package handlers_test
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	handlers "github.com/be-heroes/ultron/internal/handlers"
+	ultron "github.com/be-heroes/ultron/pkg"
+)
+
+func serveClientAuth(handler *handlers.ClientAuthHandler, connectionState *tls.ConnectionState) (int, bool) {
+	called := false
+	next := func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		w.WriteHeader(http.StatusOK)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/mutate", nil)
+	req.TLS = connectionState
+	w := httptest.NewRecorder()
+
+	handler.Wrap(next)(w, req)
+
+	return w.Result().StatusCode, called
+}
+
+func newVerifiedConnectionState(commonName string) *tls.ConnectionState {
+	return &tls.ConnectionState{
+		VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: commonName}}}},
+	}
+}
+
+func TestClientAuthHandler_AllowedCommonName(t *testing.T) {
+	handler := handlers.NewClientAuthHandler(&ultron.Config{ClientAllowedCommonNamesCSV: "front-proxy-client, kube-apiserver"})
+
+	statusCode, called := serveClientAuth(handler, newVerifiedConnectionState("kube-apiserver"))
+
+	assert.Equal(t, http.StatusOK, statusCode, "Expected status code 200")
+	assert.True(t, called, "Expected the request to reach the admission handler")
+}
+
+func TestClientAuthHandler_DisallowedCommonName(t *testing.T) {
+	handler := handlers.NewClientAuthHandler(&ultron.Config{ClientAllowedCommonNamesCSV: "kube-apiserver"})
+
+	statusCode, called := serveClientAuth(handler, newVerifiedConnectionState("system:node:worker-1"))
+
+	assert.Equal(t, http.StatusForbidden, statusCode, "Expected status code 403")
+	assert.False(t, called)
+}
+
+func TestClientAuthHandler_AnyCommonNameWithoutAllowList(t *testing.T) {
+	handler := handlers.NewClientAuthHandler(nil)
+
+	statusCode, called := serveClientAuth(handler, newVerifiedConnectionState("system:node:worker-1"))
+
+	assert.Equal(t, http.StatusOK, statusCode, "Expected status code 200")
+	assert.True(t, called)
+}
+
+func TestClientAuthHandler_NoClientCertificate(t *testing.T) {
+	handler := handlers.NewClientAuthHandler(&ultron.Config{ClientAllowedCommonNamesCSV: "kube-apiserver"})
+
+	plainStatusCode, plainCalled := serveClientAuth(handler, nil)
+	unverifiedStatusCode, unverifiedCalled := serveClientAuth(handler, &tls.ConnectionState{})
+
+	assert.Equal(t, http.StatusUnauthorized, plainStatusCode, "Expected status code 401")
+	assert.False(t, plainCalled)
+	assert.Equal(t, http.StatusUnauthorized, unverifiedStatusCode, "Expected status code 401")
+	assert.False(t, unverifiedCalled)
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+	tracing "github.com/be-heroes/ultron/pkg/tracing"
+
+	"go.opentelemetry.io/otel/trace"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+type IExplainHandler interface {
+	ExplainPodSpec(w http.ResponseWriter, r *http.Request)
+}
+
+type ExplainHandler struct {
+	computeService services.IComputeService
+}
+
+type ExplainResponse struct {
+	Pod        string                        `json:"pod"`
+	Namespace  string                        `json:"namespace,omitempty"`
+	Candidates []ultron.CandidateExplanation `json:"candidates"`
+}
+
+func NewExplainHandler(computeService services.IComputeService) *ExplainHandler {
+	return &ExplainHandler{
+		computeService: computeService,
+	}
+}
+
+func (eh *ExplainHandler) ExplainPodSpec(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(tracing.ExtractHttpContext(r), "ExplainHandler.ExplainPodSpec", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		log.Printf("Could not read request body: %v", err)
+		http.Error(w, "could not read request body", http.StatusBadRequest)
+
+		return
+	}
+
+	var pod corev1.Pod
+	if err := json.Unmarshal(body, &pod); err != nil {
+		log.Printf("Could not unmarshal request: %v", err)
+		http.Error(w, "could not unmarshal request", http.StatusBadRequest)
+
+		return
+	}
+
+	candidates, err := eh.computeService.ExplainPodSpec(ctx, &pod)
+	if err != nil {
+		log.Printf("Could not explain pod spec: %v", err)
+		http.Error(w, "could not explain pod spec", http.StatusInternalServerError)
+
+		return
+	}
+
+	respBytes, err := json.Marshal(ExplainResponse{
+		Pod:        pod.Name,
+		Namespace:  pod.Namespace,
+		Candidates: candidates,
+	})
+	if err != nil {
+		log.Printf("Could not marshal response: %v", err)
+		http.Error(w, "could not marshal response", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	if _, err := w.Write(respBytes); err != nil {
+		log.Printf("Could not write response: %v", err)
+	}
+}
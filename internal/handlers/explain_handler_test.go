@@ -0,0 +1,83 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	handlers "github.com/be-heroes/ultron/internal/handlers"
+	"github.com/be-heroes/ultron/mocks"
+	ultron "github.com/be-heroes/ultron/pkg"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestExplainPodSpec_Success(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	handler := handlers.NewExplainHandler(mockComputeService)
+
+	mockComputeService.On("ExplainPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return([]ultron.CandidateExplanation{
+		{
+			Rank:     1,
+			Selector: map[string]string{ultron.LabelHostName: "node1"},
+			Eligible: true,
+			Explanation: ultron.ScoreExplanation{
+				Components: []ultron.ScoreComponent{{Name: ultron.ScoreComponentResource, Weight: 1, Score: 0.5, Contribution: 0.5}},
+				TotalScore: 0.5,
+			},
+		},
+	}, nil)
+
+	rawPod, _ := json.Marshal(corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"}})
+	req := httptest.NewRequest(http.MethodPost, "/explain", bytes.NewBuffer(rawPod))
+	w := httptest.NewRecorder()
+
+	handler.ExplainPodSpec(w, req)
+
+	resp := w.Result()
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Expected status code 200")
+
+	var explainResp handlers.ExplainResponse
+	err := json.Unmarshal(body, &explainResp)
+	assert.NoError(t, err, "Expected valid explain response")
+	assert.Equal(t, "test-pod", explainResp.Pod)
+	assert.Len(t, explainResp.Candidates, 1)
+	assert.Equal(t, 0.5, explainResp.Candidates[0].Explanation.TotalScore)
+	assert.Equal(t, ultron.ScoreComponentResource, explainResp.Candidates[0].Explanation.Components[0].Name)
+}
+
+func TestExplainPodSpec_MethodNotAllowed(t *testing.T) {
+	handler := handlers.NewExplainHandler(new(mocks.IComputeService))
+
+	req := httptest.NewRequest(http.MethodGet, "/explain", nil)
+	w := httptest.NewRecorder()
+
+	handler.ExplainPodSpec(w, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Result().StatusCode, "Expected status code 405")
+}
+
+func TestExplainPodSpec_ComputeFailure(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	handler := handlers.NewExplainHandler(mockComputeService)
+
+	mockComputeService.On("ExplainPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return(nil, fmt.Errorf("key not found"))
+
+	rawPod, _ := json.Marshal(corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod"}})
+	req := httptest.NewRequest(http.MethodPost, "/explain", bytes.NewBuffer(rawPod))
+	w := httptest.NewRecorder()
+
+	handler.ExplainPodSpec(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode, "Expected status code 500")
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sync/atomic"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+	services "github.com/be-heroes/ultron/pkg/services"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type DegradedStats struct {
+	FailedOpen   uint64
+	FailedClosed uint64
+}
+
+type degradedDecisions struct {
+	failedOpen   atomic.Uint64
+	failedClosed atomic.Uint64
+}
+
+func (d *degradedDecisions) Stats() DegradedStats {
+	return DegradedStats{
+		FailedOpen:   d.failedOpen.Load(),
+		FailedClosed: d.failedClosed.Load(),
+	}
+}
+
+// newResponse turns an internal error into an admission decision according to the failure policy
+// resolved for the pod, so the API server never sees a bare HTTP error from Ultron.
+func (d *degradedDecisions) newResponse(ctx context.Context, kubernetesService services.IKubernetesService, pod *corev1.Pod, defaultPolicy ultron.FailurePolicy, operation string, err error) (*admissionv1.AdmissionResponse, string) {
+	policy := ultron.FailurePolicy(resolveAnnotation(ctx, kubernetesService, pod, ultron.AnnotationFailurePolicy))
+	if !policy.IsValid() {
+		policy = defaultPolicy
+	}
+
+	log.Printf("Could not complete %s for pod %s/%s, failing %s: %v", operation, pod.Namespace, pod.Name, policy, err)
+
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetAttributes(attribute.String("ultron.failure_policy", string(policy)))
+
+	warning := fmt.Sprintf("ultron: %s degraded, failing %s: %v", operation, policy, err)
+
+	if policy == ultron.FailurePolicyClosed {
+		d.failedClosed.Add(1)
+
+		return &admissionv1.AdmissionResponse{
+			Allowed: false,
+			Result: &metav1.Status{
+				Status:  metav1.StatusFailure,
+				Message: fmt.Sprintf("ultron could not complete %s for pod %s: %v", operation, pod.Name, err),
+				Reason:  metav1.StatusReasonInternalError,
+				Code:    http.StatusInternalServerError,
+			},
+			Warnings: []string{warning},
+		}, metrics.OutcomeFailedClosed
+	}
+
+	d.failedOpen.Add(1)
+
+	return &admissionv1.AdmissionResponse{
+		Allowed:  true,
+		Warnings: []string{warning},
+	}, metrics.OutcomeFailedOpen
+}
+
+func admissionOutcome(admissionResponse *admissionv1.AdmissionResponse) string {
+	if !admissionResponse.Allowed {
+		return metrics.OutcomeDenied
+	}
+
+	if len(admissionResponse.Patch) > 0 {
+		return metrics.OutcomePatched
+	}
+
+	return metrics.OutcomeAllowed
+}
+
+func newRequestPod(request *admissionv1.AdmissionRequest) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      request.Name,
+			Namespace: request.Namespace,
+		},
+	}
+}
+
+func newFailurePolicy(config *ultron.Config) ultron.FailurePolicy {
+	if !config.AdmissionFailurePolicy.IsValid() {
+		return ultron.FailurePolicyOpen
+	}
+
+	return config.AdmissionFailurePolicy
+}
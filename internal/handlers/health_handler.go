@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	HealthStatusOk          = "ok"
+	HealthStatusUnavailable = "unavailable"
+
+	healthCheckTimeout = 2 * time.Second
+)
+
+type IHealthHandler interface {
+	Livez(w http.ResponseWriter, r *http.Request)
+	Readyz(w http.ResponseWriter, r *http.Request)
+}
+
+type HealthHandler struct {
+	cacheService        services.ICacheService
+	redisClient         *redis.Client
+	certificateProvider func() (*tls.Certificate, error)
+	cacheKeys           []string
+	maxCacheAge         time.Duration
+}
+
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
+type HealthResponse struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks,omitempty"`
+}
+
+func NewHealthHandler(cacheService services.ICacheService, redisClient *redis.Client, certificateProvider func() (*tls.Certificate, error), config *ultron.Config) *HealthHandler {
+	if config == nil {
+		config = &ultron.Config{}
+	}
+
+	cacheKeys := []string{ultron.CacheKeyWeightedNodes}
+
+	// Compute configurations are only ever loaded when a source is configured.
+	if config.ComputeConfigurationSource != "" {
+		cacheKeys = append(cacheKeys, ultron.CacheKeyDurableComputeConfigurations, ultron.CacheKeyEphemeralComputeConfigurations)
+	}
+
+	return &HealthHandler{
+		cacheService:        cacheService,
+		redisClient:         redisClient,
+		certificateProvider: certificateProvider,
+		cacheKeys:           cacheKeys,
+		maxCacheAge:         config.ReadinessMaxCacheAge,
+	}
+}
+
+func (hh *HealthHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	writeHealthResponse(w, HealthResponse{Status: HealthStatusOk})
+}
+
+func (hh *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+	defer cancel()
+
+	response := HealthResponse{Status: HealthStatusOk}
+
+	if hh.redisClient != nil {
+		response.Checks = append(response.Checks, hh.checkRedis(ctx))
+	}
+
+	for _, key := range hh.cacheKeys {
+		response.Checks = append(response.Checks, hh.checkCacheKey(ctx, key))
+	}
+
+	if hh.certificateProvider != nil {
+		response.Checks = append(response.Checks, hh.checkCertificate())
+	}
+
+	for _, check := range response.Checks {
+		if !check.Healthy {
+			response.Status = HealthStatusUnavailable
+
+			break
+		}
+	}
+
+	writeHealthResponse(w, response)
+}
+
+func (hh *HealthHandler) checkRedis(ctx context.Context) HealthCheck {
+	if err := hh.redisClient.Ping(ctx).Err(); err != nil {
+		return HealthCheck{Name: "redis", Message: err.Error()}
+	}
+
+	return HealthCheck{Name: "redis", Healthy: true}
+}
+
+func (hh *HealthHandler) checkCacheKey(ctx context.Context, key string) HealthCheck {
+	name := "cache:" + key
+
+	if _, err := hh.cacheService.GetCacheItem(ctx, key); err != nil {
+		if errors.Is(err, services.ErrCacheKeyNotFound) {
+			return HealthCheck{Name: name, Message: "not present"}
+		}
+
+		return HealthCheck{Name: name, Message: err.Error()}
+	}
+
+	updatedAt, found := hh.cacheService.GetCacheItemUpdatedAt(key)
+	if !found {
+		return HealthCheck{Name: name, Healthy: true, Message: "present, age unknown"}
+	}
+
+	age := time.Since(updatedAt).Round(time.Second)
+
+	if hh.maxCacheAge > 0 && age > hh.maxCacheAge {
+		return HealthCheck{Name: name, Message: fmt.Sprintf("stale, age %s exceeds %s", age, hh.maxCacheAge)}
+	}
+
+	return HealthCheck{Name: name, Healthy: true, Message: fmt.Sprintf("age %s", age)}
+}
+
+func (hh *HealthHandler) checkCertificate() HealthCheck {
+	certificate, err := hh.certificateProvider()
+	if err != nil {
+		return HealthCheck{Name: "certificate", Message: err.Error()}
+	}
+
+	if certificate == nil || len(certificate.Certificate) == 0 {
+		return HealthCheck{Name: "certificate", Message: "no certificate loaded"}
+	}
+
+	leaf := certificate.Leaf
+	if leaf == nil {
+		leaf, err = x509.ParseCertificate(certificate.Certificate[0])
+		if err != nil {
+			return HealthCheck{Name: "certificate", Message: err.Error()}
+		}
+	}
+
+	now := time.Now()
+
+	if now.Before(leaf.NotBefore) {
+		return HealthCheck{Name: "certificate", Message: fmt.Sprintf("not valid before %s", leaf.NotBefore.Format(time.RFC3339))}
+	}
+
+	if now.After(leaf.NotAfter) {
+		return HealthCheck{Name: "certificate", Message: fmt.Sprintf("expired at %s", leaf.NotAfter.Format(time.RFC3339))}
+	}
+
+	return HealthCheck{Name: "certificate", Healthy: true, Message: fmt.Sprintf("valid until %s", leaf.NotAfter.Format(time.RFC3339))}
+}
+
+func writeHealthResponse(w http.ResponseWriter, response HealthResponse) {
+	respBytes, err := json.Marshal(response)
+	if err != nil {
+		log.Printf("Could not marshal response: %v", err)
+		http.Error(w, "could not marshal response", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if response.Status == HealthStatusOk {
+		w.WriteHeader(http.StatusOK)
+	} else {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	if _, err := w.Write(respBytes); err != nil {
+		log.Printf("Could not write response: %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package handlers_test
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	handlers "github.com/be-heroes/ultron/internal/handlers"
+	"github.com/be-heroes/ultron/mocks"
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+)
+
+func newTestCertificateProvider(t *testing.T) func() (*tls.Certificate, error) {
+	cert, err := services.NewCertificateService().GenerateSelfSignedCert("be-heroes", "ultron-service.default.svc", nil, nil)
+	assert.NoError(t, err, "GenerateSelfSignedCert should not return an error")
+
+	return func() (*tls.Certificate, error) { return &cert, nil }
+}
+
+func serveReadyz(handler *handlers.HealthHandler) (*http.Response, handlers.HealthResponse) {
+	req := httptest.NewRequest(http.MethodGet, "/readyz", nil)
+	w := httptest.NewRecorder()
+
+	handler.Readyz(w, req)
+
+	var healthResponse handlers.HealthResponse
+	_ = json.NewDecoder(w.Result().Body).Decode(&healthResponse)
+
+	return w.Result(), healthResponse
+}
+
+func TestLivez(t *testing.T) {
+	handler := handlers.NewHealthHandler(nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/livez", nil)
+	w := httptest.NewRecorder()
+
+	handler.Livez(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode, "Expected status code 200")
+}
+
+func TestReadyz_Ready(t *testing.T) {
+	server := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	mockCacheService := new(mocks.ICacheService)
+
+	mockCacheService.On("GetCacheItem", mock.Anything, ultron.CacheKeyWeightedNodes).Return([]ultron.WeightedNode{}, nil)
+	mockCacheService.On("GetCacheItemUpdatedAt", ultron.CacheKeyWeightedNodes).Return(time.Now(), true)
+
+	handler := handlers.NewHealthHandler(mockCacheService, redisClient, newTestCertificateProvider(t), &ultron.Config{ReadinessMaxCacheAge: time.Minute})
+
+	resp, healthResponse := serveReadyz(handler)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Expected status code 200")
+	assert.Equal(t, handlers.HealthStatusOk, healthResponse.Status)
+	assert.Len(t, healthResponse.Checks, 3, "Expected redis, cache and certificate checks")
+
+	for _, check := range healthResponse.Checks {
+		assert.True(t, check.Healthy, "Expected check %s to be healthy", check.Name)
+	}
+}
+
+func TestReadyz_MissingConfigurationKeys(t *testing.T) {
+	mockCacheService := new(mocks.ICacheService)
+
+	mockCacheService.On("GetCacheItem", mock.Anything, ultron.CacheKeyWeightedNodes).Return([]ultron.WeightedNode{}, nil)
+	mockCacheService.On("GetCacheItemUpdatedAt", ultron.CacheKeyWeightedNodes).Return(time.Time{}, false)
+	mockCacheService.On("GetCacheItem", mock.Anything, mock.Anything).Return(nil, services.ErrCacheKeyNotFound)
+
+	handler := handlers.NewHealthHandler(mockCacheService, nil, nil, &ultron.Config{ComputeConfigurationSource: ultron.ComputeConfigurationSourceFile})
+
+	resp, healthResponse := serveReadyz(handler)
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode, "Expected status code 503")
+	assert.Equal(t, handlers.HealthStatusUnavailable, healthResponse.Status)
+	assert.Len(t, healthResponse.Checks, 3, "Expected one check per cache key")
+	assert.True(t, healthResponse.Checks[0].Healthy, "Expected weighted nodes to be healthy")
+	assert.False(t, healthResponse.Checks[1].Healthy, "Expected durable configurations to be missing")
+	assert.False(t, healthResponse.Checks[2].Healthy, "Expected ephemeral configurations to be missing")
+}
+
+func TestReadyz_StaleCacheAndRedisDown(t *testing.T) {
+	server := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	mockCacheService := new(mocks.ICacheService)
+
+	server.Close()
+
+	mockCacheService.On("GetCacheItem", mock.Anything, ultron.CacheKeyWeightedNodes).Return([]ultron.WeightedNode{}, nil)
+	mockCacheService.On("GetCacheItemUpdatedAt", ultron.CacheKeyWeightedNodes).Return(time.Now().Add(-time.Hour), true)
+
+	handler := handlers.NewHealthHandler(mockCacheService, redisClient, nil, &ultron.Config{ReadinessMaxCacheAge: time.Minute})
+
+	resp, healthResponse := serveReadyz(handler)
+
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode, "Expected status code 503")
+	assert.False(t, healthResponse.Checks[0].Healthy, "Expected redis to be unhealthy")
+	assert.False(t, healthResponse.Checks[1].Healthy, "Expected weighted nodes to be stale")
+	assert.Contains(t, healthResponse.Checks[1].Message, "stale")
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	admissionTimeoutMargin  = 250 * time.Millisecond
+	defaultAdmissionTimeout = 10 * time.Second
+)
+
+func resolveAnnotation(ctx context.Context, kubernetesService services.IKubernetesService, pod *corev1.Pod, key string) string {
+	if value, exists := pod.Annotations[key]; exists {
+		return value
+	}
+
+	if kubernetesService == nil || pod.Namespace == "" {
+		return ""
+	}
+
+	namespace, err := kubernetesService.GetNamespace(ctx, pod.Namespace)
+	if err != nil || namespace == nil {
+		return ""
+	}
+
+	return namespace.Annotations[key]
+}
+
+func resolveBoolAnnotation(ctx context.Context, kubernetesService services.IKubernetesService, pod *corev1.Pod, key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(resolveAnnotation(ctx, kubernetesService, pod, key))
+	if err != nil {
+		return defaultValue
+	}
+
+	return value
+}
+
+// newAdmissionContext bounds a review by the webhook timeout the API server appends as a query
+// parameter, keeping a margin so a degraded response still reaches it in time.
+func newAdmissionContext(ctx context.Context, r *http.Request, defaultTimeout time.Duration) (context.Context, context.CancelFunc) {
+	timeout := defaultTimeout
+
+	if value := r.URL.Query().Get("timeout"); value != "" {
+		if requestTimeout, err := time.ParseDuration(value); err == nil && requestTimeout > 0 {
+			timeout = requestTimeout
+		}
+	}
+
+	if timeout > 2*admissionTimeoutMargin {
+		timeout -= admissionTimeoutMargin
+	}
+
+	return context.WithTimeout(ctx, timeout)
+}
+
+func newAdmissionTimeout(config *ultron.Config) time.Duration {
+	if config.AdmissionTimeout <= 0 {
+		return defaultAdmissionTimeout
+	}
+
+	return config.AdmissionTimeout
+}
+
+func escapeJsonPointer(value string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(value)
+}
+
+func newAnnotationPatch(pod *corev1.Pod, metadataPath string, key string, value string) map[string]interface{} {
+	if pod.Annotations == nil {
+		return map[string]interface{}{
+			"op":    "add",
+			"path":  metadataPath + "/annotations",
+			"value": map[string]string{key: value},
+		}
+	}
+
+	return map[string]interface{}{
+		"op":    "add",
+		"path":  metadataPath + "/annotations/" + escapeJsonPointer(key),
+		"value": value,
+	}
+}
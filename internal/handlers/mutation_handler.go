@@ -1,33 +1,81 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"log"
 	"net/http"
+	"time"
 
+	ultron "github.com/be-heroes/ultron/pkg"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
 	services "github.com/be-heroes/ultron/pkg/services"
+	tracing "github.com/be-heroes/ultron/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	admissionv1 "k8s.io/api/admission/v1"
-	corev1 "k8s.io/api/core/v1"
 )
 
 type IMutationHandler interface {
 	MutatePodSpec(w http.ResponseWriter, r *http.Request)
-	HandleAdmissionReview(request *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error)
+	HandleAdmissionReview(ctx context.Context, request *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error)
+	DegradedStats() DegradedStats
 }
 
 type MutationHandler struct {
-	computeService services.IComputeService
+	computeService      services.IComputeService
+	kubernetesService   services.IKubernetesService
+	dryRun              bool
+	placementMode       ultron.PlacementMode
+	precedence          ultron.SelectorPrecedence
+	preferredCandidates int
+	timeout             time.Duration
+	failurePolicy       ultron.FailurePolicy
+	degradedDecisions   degradedDecisions
 }
 
-func NewMutationHandler(computeService services.IComputeService) *MutationHandler {
+func NewMutationHandler(computeService services.IComputeService, kubernetesService services.IKubernetesService, config *ultron.Config) *MutationHandler {
+	if config == nil {
+		config = &ultron.Config{}
+	}
+
+	placementMode := config.MutationPlacementMode
+	if !placementMode.IsValid() {
+		placementMode = ultron.PlacementModeNodeSelector
+	}
+
+	precedence := config.MutationSelectorPrecedence
+	if !precedence.IsValid() {
+		precedence = ultron.SelectorPrecedenceWorkload
+	}
+
+	preferredCandidates := config.MutationPreferredCandidates
+	if preferredCandidates < 1 {
+		preferredCandidates = ultron.DefaultPreferredCandidates
+	}
+
 	return &MutationHandler{
-		computeService: computeService,
+		computeService:      computeService,
+		kubernetesService:   kubernetesService,
+		dryRun:              config.MutationDryRun,
+		placementMode:       placementMode,
+		precedence:          precedence,
+		preferredCandidates: preferredCandidates,
+		timeout:             newAdmissionTimeout(config),
+		failurePolicy:       newFailurePolicy(config),
 	}
 }
 
 func (mh *MutationHandler) MutatePodSpec(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(tracing.ExtractHttpContext(r), "MutationHandler.MutatePodSpec", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	ctx, cancel := newAdmissionContext(ctx, r, mh.timeout)
+	defer cancel()
+
 	var admissionReviewReq admissionv1.AdmissionReview
 	var admissionReviewResp admissionv1.AdmissionReview
 
@@ -46,7 +94,7 @@ func (mh *MutationHandler) MutatePodSpec(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	admissionResponse, err := mh.HandleAdmissionReview(admissionReviewReq.Request)
+	admissionResponse, err := mh.HandleAdmissionReview(ctx, admissionReviewReq.Request)
 	if err != nil {
 		log.Printf("Could not handle admission review: %v", err)
 		http.Error(w, "could not handle admission review", http.StatusInternalServerError)
@@ -76,21 +124,58 @@ func (mh *MutationHandler) MutatePodSpec(w http.ResponseWriter, r *http.Request)
 	}
 }
 
-func (mh *MutationHandler) HandleAdmissionReview(request *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
-	if request.Kind.Kind != "Pod" {
+func (mh *MutationHandler) HandleAdmissionReview(ctx context.Context, request *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	start := time.Now()
+
+	admissionResponse, outcome := mh.admit(metrics.WithCandidateRecording(ctx), request)
+
+	metrics.ObserveAdmission(metrics.HandlerMutation, outcome, time.Since(start))
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("ultron.admission.outcome", outcome))
+
+	return admissionResponse, nil
+}
+
+func (mh *MutationHandler) admit(ctx context.Context, request *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, string) {
+	template, err := decodePodTemplate(request)
+	if err != nil {
+		return mh.degradedDecisions.newResponse(ctx, mh.kubernetesService, newRequestPod(request), mh.failurePolicy, metrics.HandlerMutation, err)
+	}
+
+	if template == nil || template.validationOnly {
 		return &admissionv1.AdmissionResponse{
 			Allowed: true,
-		}, nil
+		}, metrics.OutcomeSkipped
 	}
 
-	var pod corev1.Pod
-	if err := json.Unmarshal(request.Object.Raw, &pod); err != nil {
+	if template.workload && workloadTemplateUnchanged(request) {
 		return &admissionv1.AdmissionResponse{
 			Allowed: true,
-		}, err
+		}, metrics.OutcomeSkipped
+	}
+
+	// Pods, ReplicaSets and Jobs created from a placed template already carry its placement.
+	if template.pod.Annotations[ultron.AnnotationPlaced] != "" {
+		return &admissionv1.AdmissionResponse{
+			Allowed: true,
+		}, metrics.OutcomeSkipped
+	}
+
+	admissionResponse, err := mh.mutatePodTemplate(ctx, template)
+	if err != nil {
+		return mh.degradedDecisions.newResponse(ctx, mh.kubernetesService, &template.pod, mh.failurePolicy, metrics.HandlerMutation, err)
 	}
 
-	wNode, err := mh.computeService.MatchPodSpec(&pod)
+	return admissionResponse, admissionOutcome(admissionResponse)
+}
+
+func (mh *MutationHandler) DegradedStats() DegradedStats {
+	return mh.degradedDecisions.Stats()
+}
+
+func (mh *MutationHandler) mutatePodTemplate(ctx context.Context, template *podTemplate) (*admissionv1.AdmissionResponse, error) {
+	pod := template.pod
+
+	wNode, err := mh.computeService.MatchPodSpec(ctx, &pod)
 	if err != nil {
 		return nil, err
 	}
@@ -101,23 +186,75 @@ func (mh *MutationHandler) HandleAdmissionReview(request *admissionv1.AdmissionR
 		}, nil
 	}
 
-	pod.Spec.NodeSelector = wNode.Selector
-	patchBytes, err := json.Marshal([]map[string]interface{}{
-		{
-			"op":    "add",
-			"path":  "/spec/nodeSelector",
-			"value": pod.Spec.NodeSelector,
-		},
-	})
-	if err != nil {
+	var patch []map[string]interface{}
+	var warnings []string
+
+	if resolveBoolAnnotation(ctx, mh.kubernetesService, &pod, ultron.AnnotationDryRun, mh.dryRun) {
+		selectorBytes, err := json.Marshal(wNode.Selector)
+		if err != nil {
+			return nil, err
+		}
+
+		log.Printf("Dry run: pod %s/%s would be placed with node selector %s", pod.Namespace, pod.Name, selectorBytes)
+
+		patch = append(patch, newAnnotationPatch(&pod, template.metadataPath, ultron.AnnotationShadowSelector, string(selectorBytes)))
+	} else {
+		placementMode := ultron.PlacementMode(resolveAnnotation(ctx, mh.kubernetesService, &pod, ultron.AnnotationPlacementMode))
+		if !placementMode.IsValid() {
+			placementMode = mh.placementMode
+		}
+
+		precedence := ultron.SelectorPrecedence(resolveAnnotation(ctx, mh.kubernetesService, &pod, ultron.AnnotationSelectorPrecedence))
+		if !precedence.IsValid() {
+			precedence = mh.precedence
+		}
+
+		candidates := []placementCandidate{{selector: wNode.Selector, weight: preferredAffinityWeight}}
+
+		if placementMode == ultron.PlacementModePreferredAffinity {
+			rankedNodes, err := mh.computeService.RankPodSpec(ctx, &pod, mh.preferredCandidates)
+			if err != nil {
+				log.Printf("Could not rank candidates for pod %s/%s: %v", pod.Namespace, pod.Name, err)
+			} else if rankedCandidates := newPlacementCandidates(rankedNodes); len(rankedCandidates) > 0 {
+				candidates = rankedCandidates
+			}
+		}
+
+		if template.workload {
+			candidates = withoutHostName(candidates)
+		}
+
+		patch, warnings = newPlacementPatch(&pod, template.specPath, candidates, placementMode, precedence)
+
+		if template.workload && len(patch) > 0 {
+			patch = append(patch, newAnnotationPatch(&pod, template.metadataPath, ultron.AnnotationPlaced, "true"))
+		}
+
+		if len(patch) > 0 && wNode.Selector[ultron.LabelComputeType] != "" {
+			metrics.ComputeConfigurationFallbacksTotal.WithLabelValues(wNode.Annotations[ultron.AnnotationInstanceType]).Inc()
+		}
+
+		for _, warning := range warnings {
+			log.Printf("Pod %s/%s: %s", pod.Namespace, pod.Name, warning)
+		}
+	}
+
+	if len(patch) == 0 {
 		return &admissionv1.AdmissionResponse{
-			Allowed: true,
-		}, err
+			Allowed:  true,
+			Warnings: warnings,
+		}, nil
+	}
+
+	patchBytes, err := json.Marshal(patch)
+	if err != nil {
+		return nil, err
 	}
 
 	return &admissionv1.AdmissionResponse{
 		Allowed:   true,
 		Patch:     patchBytes,
 		PatchType: func() *admissionv1.PatchType { pt := admissionv1.PatchTypeJSONPatch; return &pt }(),
+		Warnings:  warnings,
 	}, nil
 }
@@ -2,19 +2,26 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/be-heroes/ultron/internal/handlers"
 	"github.com/be-heroes/ultron/mocks" // Import the generated mocks
 	ultron "github.com/be-heroes/ultron/pkg"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -22,14 +29,16 @@ import (
 
 // Utility functions to help create test data
 func intPtr(i int32) *int32         { return &i }
+func int64Ptr(i int64) *int64       { return &i }
 func float32Ptr(f float32) *float32 { return &f }
+func float64Ptr(f float64) *float64 { return &f }
 func stringPtr(s string) *string    { return &s }
 
 func TestMutatePods_Success(t *testing.T) {
 	mockComputeService := new(mocks.IComputeService)
-	handler := handlers.NewMutationHandler(mockComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, nil)
 
-	mockComputeService.On("MatchPodSpec", mock.AnythingOfType("*v1.Pod")).
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).
 		Return(&ultron.WeightedNode{
 			Selector: map[string]string{"node-type": "mock-node"},
 		}, nil)
@@ -76,7 +85,7 @@ func TestMutatePods_Success(t *testing.T) {
 
 func TestMutatePods_InvalidBody(t *testing.T) {
 	mockComputeService := new(mocks.IComputeService)
-	handler := handlers.NewMutationHandler(mockComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/mutate", bytes.NewBuffer([]byte("invalid body")))
 	w := httptest.NewRecorder()
@@ -89,22 +98,22 @@ func TestMutatePods_InvalidBody(t *testing.T) {
 
 func TestMutationHandleAdmissionReview_NonPodKind(t *testing.T) {
 	mockComputeService := new(mocks.IComputeService)
-	handler := handlers.NewMutationHandler(mockComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, nil)
 
 	admissionRequest := &admissionv1.AdmissionRequest{
 		Kind: metav1.GroupVersionKind{Kind: "Service"},
 	}
 
-	admissionResponse, err := handler.HandleAdmissionReview(admissionRequest)
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), admissionRequest)
 	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
 	assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true for non-pod kind")
 }
 
 func TestMutationHandleAdmissionReview_PodSpecFailure(t *testing.T) {
 	mockComputeService := new(mocks.IComputeService)
-	handler := handlers.NewMutationHandler(mockComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, nil)
 
-	mockComputeService.On("MatchPodSpec", mock.AnythingOfType("*v1.Pod")).Return(nil, nil)
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return(nil, nil)
 
 	pod := corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -122,7 +131,519 @@ func TestMutationHandleAdmissionReview_PodSpecFailure(t *testing.T) {
 		},
 	}
 
-	admissionResponse, err := handler.HandleAdmissionReview(admissionRequest)
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), admissionRequest)
 	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
 	assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true")
 }
+
+func newDryRunAdmissionRequest(annotations map[string]string) *admissionv1.AdmissionRequest {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+	}
+	rawPod, _ := json.Marshal(pod)
+
+	return &admissionv1.AdmissionRequest{
+		UID:       "1234",
+		Kind:      metav1.GroupVersionKind{Kind: "Pod"},
+		Namespace: "default",
+		Object: runtime.RawExtension{
+			Raw: rawPod,
+		},
+	}
+}
+
+func TestMutationHandleAdmissionReview_DryRunConfig(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, &ultron.Config{MutationDryRun: true})
+
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).
+		Return(&ultron.WeightedNode{
+			Selector: map[string]string{"node-type": "mock-node"},
+		}, nil)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newDryRunAdmissionRequest(nil))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true")
+
+	expectedPatch := `[{"op":"add","path":"/metadata/annotations","value":{"ultron.io/shadow-selector":"{\"node-type\":\"mock-node\"}"}}]`
+	assert.Equal(t, expectedPatch, string(admissionResponse.Patch), "Expected patch to only record the shadow selector")
+}
+
+func TestMutationHandleAdmissionReview_DryRunPodAnnotation(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, nil)
+
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).
+		Return(&ultron.WeightedNode{
+			Selector: map[string]string{"node-type": "mock-node"},
+		}, nil)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newDryRunAdmissionRequest(map[string]string{ultron.AnnotationDryRun: "true"}))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+
+	expectedPatch := `[{"op":"add","path":"/metadata/annotations/ultron.io~1shadow-selector","value":"{\"node-type\":\"mock-node\"}"}]`
+	assert.Equal(t, expectedPatch, string(admissionResponse.Patch), "Expected patch to only record the shadow selector")
+}
+
+func TestMutationHandleAdmissionReview_DryRunNamespaceAnnotation(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	mockKubernetesService := new(mocks.IKubernetesService)
+	handler := handlers.NewMutationHandler(mockComputeService, mockKubernetesService, &ultron.Config{MutationDryRun: true})
+
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).
+		Return(&ultron.WeightedNode{
+			Selector: map[string]string{"node-type": "mock-node"},
+		}, nil)
+	mockKubernetesService.On("GetNamespace", mock.Anything, "default").
+		Return(&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "default",
+				Annotations: map[string]string{ultron.AnnotationDryRun: "false"},
+			},
+		}, nil)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newDryRunAdmissionRequest(nil))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+
+	expectedPatch := `[{"op":"add","path":"/spec/nodeSelector","value":{"node-type":"mock-node"}}]`
+	assert.Equal(t, expectedPatch, string(admissionResponse.Patch), "Expected namespace annotation to override global dry run")
+}
+
+func newPlacementAdmissionRequest(spec corev1.PodSpec) *admissionv1.AdmissionRequest {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "default",
+		},
+		Spec: spec,
+	}
+	rawPod, _ := json.Marshal(pod)
+
+	return &admissionv1.AdmissionRequest{
+		UID:       "1234",
+		Kind:      metav1.GroupVersionKind{Kind: "Pod"},
+		Namespace: "default",
+		Object: runtime.RawExtension{
+			Raw: rawPod,
+		},
+	}
+}
+
+func newPlacementMutationHandler(config *ultron.Config) *handlers.MutationHandler {
+	mockComputeService := new(mocks.IComputeService)
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).
+		Return(&ultron.WeightedNode{
+			Selector: map[string]string{ultron.LabelInstanceType: "t3.large"},
+		}, nil)
+	mockComputeService.On("RankPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod"), mock.Anything).Return(nil, nil)
+
+	return handlers.NewMutationHandler(mockComputeService, nil, config)
+}
+
+func TestMutationHandleAdmissionReview_CountsComputeConfigurationFallback(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).
+		Return(&ultron.WeightedNode{
+			Selector:    map[string]string{ultron.LabelComputeType: string(ultron.ComputeTypeDurable)},
+			Annotations: map[string]string{ultron.AnnotationInstanceType: "t3.medium"},
+		}, nil)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, nil)
+
+	fallbacks := metrics.ComputeConfigurationFallbacksTotal.WithLabelValues("t3.medium")
+	before := testutil.ToFloat64(fallbacks)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newPlacementAdmissionRequest(corev1.PodSpec{}))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.NotEmpty(t, admissionResponse.Patch, "Expected the fallback selector to be patched")
+	assert.Equal(t, before+1, testutil.ToFloat64(fallbacks), "Expected the emitted fallback to be counted once")
+}
+
+func TestMutationHandleAdmissionReview_MergesNodeSelector(t *testing.T) {
+	handler := newPlacementMutationHandler(nil)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newPlacementAdmissionRequest(corev1.PodSpec{
+		NodeSelector: map[string]string{"team": "a"},
+	}))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.Empty(t, admissionResponse.Warnings, "Expected no warnings without conflicts")
+
+	expectedPatch := `[{"op":"add","path":"/spec/nodeSelector","value":{"node.kubernetes.io/instance-type":"t3.large","team":"a"}}]`
+	assert.Equal(t, expectedPatch, string(admissionResponse.Patch), "Expected existing node selector to be preserved")
+}
+
+func TestMutationHandleAdmissionReview_ConflictWorkloadPrecedence(t *testing.T) {
+	handler := newPlacementMutationHandler(nil)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newPlacementAdmissionRequest(corev1.PodSpec{
+		NodeSelector: map[string]string{ultron.LabelInstanceType: "m5.large"},
+	}))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.Len(t, admissionResponse.Warnings, 1, "Expected a warning for the conflicting key")
+	assert.Nil(t, admissionResponse.Patch, "Expected workload value to win without a no-op patch")
+}
+
+func TestMutationHandleAdmissionReview_ConflictUltronPrecedence(t *testing.T) {
+	handler := newPlacementMutationHandler(&ultron.Config{MutationSelectorPrecedence: ultron.SelectorPrecedenceUltron})
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newPlacementAdmissionRequest(corev1.PodSpec{
+		NodeSelector: map[string]string{ultron.LabelInstanceType: "m5.large"},
+	}))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+
+	expectedPatch := `[{"op":"add","path":"/spec/nodeSelector","value":{"node.kubernetes.io/instance-type":"t3.large"}}]`
+	assert.Equal(t, expectedPatch, string(admissionResponse.Patch), "Expected Ultron value to win")
+}
+
+func TestMutationHandleAdmissionReview_ConflictSkipPrecedence(t *testing.T) {
+	handler := newPlacementMutationHandler(&ultron.Config{MutationSelectorPrecedence: ultron.SelectorPrecedenceSkip})
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newPlacementAdmissionRequest(corev1.PodSpec{
+		NodeSelector: map[string]string{ultron.LabelInstanceType: "m5.large"},
+	}))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true")
+	assert.Nil(t, admissionResponse.Patch, "Expected no patch when placement is skipped")
+	assert.Len(t, admissionResponse.Warnings, 2, "Expected conflict and skip warnings")
+}
+
+func TestMutationHandleAdmissionReview_RequiredAffinity(t *testing.T) {
+	handler := newPlacementMutationHandler(&ultron.Config{MutationPlacementMode: ultron.PlacementModeRequiredAffinity})
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newPlacementAdmissionRequest(corev1.PodSpec{}))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+
+	expectedPatch := `[{"op":"add","path":"/spec/affinity","value":{"nodeAffinity":{"requiredDuringSchedulingIgnoredDuringExecution":{"nodeSelectorTerms":[{"matchExpressions":[{"key":"node.kubernetes.io/instance-type","operator":"In","values":["t3.large"]}]}]}}}}]`
+	assert.Equal(t, expectedPatch, string(admissionResponse.Patch), "Expected required node affinity")
+}
+
+func TestMutationHandleAdmissionReview_EmptySelectorSkipsPlacement(t *testing.T) {
+	for _, placementMode := range []ultron.PlacementMode{ultron.PlacementModeNodeSelector, ultron.PlacementModeRequiredAffinity, ultron.PlacementModePreferredAffinity} {
+		mockComputeService := new(mocks.IComputeService)
+		mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return(&ultron.WeightedNode{}, nil)
+		mockComputeService.On("RankPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod"), mock.Anything).Return(nil, nil)
+
+		handler := handlers.NewMutationHandler(mockComputeService, nil, &ultron.Config{MutationPlacementMode: placementMode})
+
+		admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newPlacementAdmissionRequest(corev1.PodSpec{}))
+		assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+		assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true")
+		assert.Nil(t, admissionResponse.Patch, "Expected no patch without node requirements in %s mode", placementMode)
+	}
+}
+
+func TestMutationHandleAdmissionReview_RequiredAffinityConflictSkipsPlacement(t *testing.T) {
+	handler := newPlacementMutationHandler(&ultron.Config{MutationPlacementMode: ultron.PlacementModeRequiredAffinity})
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newPlacementAdmissionRequest(corev1.PodSpec{
+		NodeSelector: map[string]string{ultron.LabelInstanceType: "m5.large"},
+	}))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.Nil(t, admissionResponse.Patch, "Expected no empty required term when the workload overrides every key")
+}
+
+func TestMutationHandleAdmissionReview_PreferredAffinityAnnotation(t *testing.T) {
+	handler := newPlacementMutationHandler(nil)
+
+	request := newPlacementAdmissionRequest(corev1.PodSpec{})
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			Annotations: map[string]string{ultron.AnnotationPlacementMode: string(ultron.PlacementModePreferredAffinity)},
+		},
+	}
+	request.Object.Raw, _ = json.Marshal(pod)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), request)
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+
+	expectedPatch := `[{"op":"add","path":"/spec/affinity","value":{"nodeAffinity":{"preferredDuringSchedulingIgnoredDuringExecution":[{"weight":100,"preference":{"matchExpressions":[{"key":"node.kubernetes.io/instance-type","operator":"In","values":["t3.large"]}]}}]}}}]`
+	assert.Equal(t, expectedPatch, string(admissionResponse.Patch), "Expected preferred node affinity")
+}
+
+func TestMutationHandleAdmissionReview_PreferredAffinityRankedCandidates(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, &ultron.Config{
+		MutationPlacementMode:       ultron.PlacementModePreferredAffinity,
+		MutationPreferredCandidates: 3,
+	})
+
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).
+		Return(&ultron.WeightedNode{
+			Selector: map[string]string{ultron.LabelHostName: "node1"},
+		}, nil)
+	mockComputeService.On("RankPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod"), 3).
+		Return([]ultron.RankedWeightedNode{
+			{Node: ultron.WeightedNode{Selector: map[string]string{ultron.LabelHostName: "node1"}}, TotalScore: 4},
+			{Node: ultron.WeightedNode{Selector: map[string]string{ultron.LabelHostName: "node2"}}, TotalScore: 3},
+			{Node: ultron.WeightedNode{Selector: map[string]string{ultron.LabelHostName: "node3"}}, TotalScore: 2},
+		}, nil)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newPlacementAdmissionRequest(corev1.PodSpec{}))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+
+	var patch []struct {
+		Path  string          `json:"path"`
+		Value corev1.Affinity `json:"value"`
+	}
+	err = json.Unmarshal(admissionResponse.Patch, &patch)
+	assert.NoError(t, err, "Expected a valid JSON patch")
+	assert.Len(t, patch, 1)
+	assert.Equal(t, "/spec/affinity", patch[0].Path)
+
+	terms := patch[0].Value.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution
+	assert.Len(t, terms, 3, "Expected a preferred term per ranked candidate")
+	assert.Equal(t, int32(100), terms[0].Weight)
+	assert.Equal(t, "node1", terms[0].Preference.MatchExpressions[0].Values[0])
+	assert.Equal(t, int32(51), terms[1].Weight)
+	assert.Equal(t, "node2", terms[1].Preference.MatchExpressions[0].Values[0])
+	assert.Equal(t, int32(1), terms[2].Weight)
+	assert.Equal(t, "node3", terms[2].Preference.MatchExpressions[0].Values[0])
+}
+
+func TestMutationHandleAdmissionReview_Deployment(t *testing.T) {
+	handler := newPlacementMutationHandler(nil)
+
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-deployment",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{NodeSelector: map[string]string{"team": "a"}},
+			},
+		},
+	}
+	rawDeployment, _ := json.Marshal(deployment)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), &admissionv1.AdmissionRequest{
+		UID:    "1234",
+		Kind:   metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		Object: runtime.RawExtension{Raw: rawDeployment},
+	})
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+
+	expectedPatch := `[{"op":"add","path":"/spec/template/spec/nodeSelector","value":{"node.kubernetes.io/instance-type":"t3.large","team":"a"}},{"op":"add","path":"/spec/template/metadata/annotations","value":{"ultron.io/placed":"true"}}]`
+	assert.Equal(t, expectedPatch, string(admissionResponse.Patch), "Expected patch to target the pod template")
+}
+
+func newDeploymentAdmissionRequest(operation admissionv1.Operation, replicas int32, oldReplicas int32) *admissionv1.AdmissionRequest {
+	deployment := appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-deployment",
+			Namespace: "default",
+		},
+		Spec: appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	rawDeployment, _ := json.Marshal(deployment)
+
+	deployment.Spec.Replicas = &oldReplicas
+	rawOldDeployment, _ := json.Marshal(deployment)
+
+	return &admissionv1.AdmissionRequest{
+		UID:       "1234",
+		Kind:      metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		Operation: operation,
+		Object:    runtime.RawExtension{Raw: rawDeployment},
+		OldObject: runtime.RawExtension{Raw: rawOldDeployment},
+	}
+}
+
+func TestMutationHandleAdmissionReview_DeploymentDropsHostName(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).
+		Return(&ultron.WeightedNode{
+			Selector: map[string]string{ultron.LabelInstanceType: "t3.large", ultron.LabelHostName: "node1"},
+		}, nil)
+
+	handler := handlers.NewMutationHandler(mockComputeService, nil, nil)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newDeploymentAdmissionRequest(admissionv1.Create, 3, 0))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+
+	expectedPatch := `[{"op":"add","path":"/spec/template/spec/nodeSelector","value":{"node.kubernetes.io/instance-type":"t3.large"}},{"op":"add","path":"/spec/template/metadata/annotations","value":{"ultron.io/placed":"true"}}]`
+	assert.Equal(t, expectedPatch, string(admissionResponse.Patch), "Expected replicas not to be pinned to a single host")
+}
+
+func TestMutationHandleAdmissionReview_DeploymentScaleSkipsPlacement(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, nil)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newDeploymentAdmissionRequest(admissionv1.Update, 5, 3))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true")
+	assert.Nil(t, admissionResponse.Patch, "Expected no placement when the template is unchanged")
+	mockComputeService.AssertNotCalled(t, "MatchPodSpec", mock.Anything, mock.Anything)
+}
+
+func TestMutationHandleAdmissionReview_DaemonSetSkipped(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, nil)
+
+	rawDaemonSet, _ := json.Marshal(appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: "test-daemonset", Namespace: "default"}})
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), &admissionv1.AdmissionRequest{
+		UID:    "1234",
+		Kind:   metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+		Object: runtime.RawExtension{Raw: rawDaemonSet},
+	})
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true")
+	assert.Nil(t, admissionResponse.Patch, "Expected DaemonSets not to be placed")
+	mockComputeService.AssertNotCalled(t, "MatchPodSpec", mock.Anything, mock.Anything)
+}
+
+func TestMutationHandleAdmissionReview_PlacedPodSkipped(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, nil)
+
+	request := newPlacementAdmissionRequest(corev1.PodSpec{})
+	request.Object.Raw, _ = json.Marshal(corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "default",
+			Annotations: map[string]string{ultron.AnnotationPlaced: "true"},
+		},
+	})
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), request)
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.Nil(t, admissionResponse.Patch, "Expected pods from a placed template not to be placed again")
+	mockComputeService.AssertNotCalled(t, "MatchPodSpec", mock.Anything, mock.Anything)
+}
+
+func TestMutationHandleAdmissionReview_PlacedReplicaSetSkipped(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, &ultron.Config{MutationPlacementMode: ultron.PlacementModePreferredAffinity})
+
+	rawReplicaSet, _ := json.Marshal(appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-deployment-5d4f8", Namespace: "default"},
+		Spec: appsv1.ReplicaSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ultron.AnnotationPlaced: "true"}},
+				Spec: corev1.PodSpec{
+					Affinity: &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{
+						PreferredDuringSchedulingIgnoredDuringExecution: []corev1.PreferredSchedulingTerm{{
+							Weight: 100,
+							Preference: corev1.NodeSelectorTerm{MatchExpressions: []corev1.NodeSelectorRequirement{
+								{Key: ultron.LabelInstanceType, Operator: corev1.NodeSelectorOpIn, Values: []string{"t3.large"}},
+							}},
+						}},
+					}},
+				},
+			},
+		},
+	})
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), &admissionv1.AdmissionRequest{
+		UID:       "1234",
+		Kind:      metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "ReplicaSet"},
+		Operation: admissionv1.Create,
+		Object:    runtime.RawExtension{Raw: rawReplicaSet},
+	})
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true")
+	assert.Nil(t, admissionResponse.Patch, "Expected a ReplicaSet from a placed Deployment not to be placed again")
+	mockComputeService.AssertNotCalled(t, "MatchPodSpec", mock.Anything, mock.Anything)
+}
+
+func TestMutationHandleAdmissionReview_CronJobDryRun(t *testing.T) {
+	handler := newPlacementMutationHandler(&ultron.Config{MutationDryRun: true})
+
+	cronJob := batchv1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cronjob",
+			Namespace: "default",
+		},
+	}
+	rawCronJob, _ := json.Marshal(cronJob)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), &admissionv1.AdmissionRequest{
+		UID:    "1234",
+		Kind:   metav1.GroupVersionKind{Group: "batch", Version: "v1", Kind: "CronJob"},
+		Object: runtime.RawExtension{Raw: rawCronJob},
+	})
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+
+	expectedPatch := `[{"op":"add","path":"/spec/jobTemplate/spec/template/metadata/annotations","value":{"ultron.io/shadow-selector":"{\"node.kubernetes.io/instance-type\":\"t3.large\"}"}}]`
+	assert.Equal(t, expectedPatch, string(admissionResponse.Patch), "Expected patch to target the job template")
+}
+
+func TestMutatePods_MatchFailureFailsOpen(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, nil)
+
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return(nil, errors.New("key not found"))
+
+	failedOpen := metrics.AdmissionRequestsTotal.WithLabelValues(metrics.HandlerMutation, metrics.OutcomeFailedOpen)
+	failedOpenBefore := testutil.ToFloat64(failedOpen)
+
+	reqBody, _ := json.Marshal(admissionv1.AdmissionReview{Request: newPlacementAdmissionRequest(corev1.PodSpec{})})
+	req := httptest.NewRequest(http.MethodPost, "/mutate", bytes.NewBuffer(reqBody))
+	w := httptest.NewRecorder()
+
+	handler.MutatePodSpec(w, req)
+
+	resp := w.Result()
+	body, _ := io.ReadAll(resp.Body)
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "Expected status code 200")
+
+	var admissionReviewResp admissionv1.AdmissionReview
+	err := json.Unmarshal(body, &admissionReviewResp)
+	assert.NoError(t, err, "Expected valid AdmissionReview response")
+	assert.True(t, admissionReviewResp.Response.Allowed, "Expected Allowed to be true when failing open")
+	assert.Nil(t, admissionReviewResp.Response.Patch, "Expected no patch when failing open")
+	assert.Len(t, admissionReviewResp.Response.Warnings, 1, "Expected a degraded decision warning")
+	assert.Equal(t, handlers.DegradedStats{FailedOpen: 1}, handler.DegradedStats(), "Expected the degraded decision to be counted")
+	assert.Equal(t, failedOpenBefore+1, testutil.ToFloat64(failedOpen), "Expected the admission outcome to be recorded")
+}
+
+func TestMutationHandleAdmissionReview_MatchFailureNamespaceFailsClosed(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	mockKubernetesService := new(mocks.IKubernetesService)
+	handler := handlers.NewMutationHandler(mockComputeService, mockKubernetesService, nil)
+
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return(nil, errors.New("key not found"))
+	mockKubernetesService.On("GetNamespace", mock.Anything, "default").
+		Return(&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "default",
+				Annotations: map[string]string{ultron.AnnotationFailurePolicy: string(ultron.FailurePolicyClosed)},
+			},
+		}, nil)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newPlacementAdmissionRequest(corev1.PodSpec{}))
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.False(t, admissionResponse.Allowed, "Expected Allowed to be false when failing closed")
+	assert.Equal(t, int32(http.StatusInternalServerError), admissionResponse.Result.Code, "Expected an internal error status")
+	assert.Len(t, admissionResponse.Warnings, 1, "Expected a degraded decision warning")
+	assert.Equal(t, handlers.DegradedStats{FailedClosed: 1}, handler.DegradedStats(), "Expected the degraded decision to be counted")
+}
+
+func TestMutatePods_RespectsWebhookTimeout(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	handler := handlers.NewMutationHandler(mockComputeService, nil, &ultron.Config{AdmissionTimeout: time.Minute})
+
+	withinWebhookTimeout := mock.MatchedBy(func(ctx context.Context) bool {
+		deadline, ok := ctx.Deadline()
+
+		return ok && time.Until(deadline) <= 5*time.Second
+	})
+
+	mockComputeService.On("MatchPodSpec", withinWebhookTimeout, mock.AnythingOfType("*v1.Pod")).Return(nil, nil)
+
+	reqBody, _ := json.Marshal(admissionv1.AdmissionReview{Request: newPlacementAdmissionRequest(corev1.PodSpec{})})
+	req := httptest.NewRequest(http.MethodPost, "/mutate?timeout=5s", bytes.NewBuffer(reqBody))
+	w := httptest.NewRecorder()
+
+	handler.MutatePodSpec(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode, "Expected status code 200")
+	mockComputeService.AssertExpectations(t)
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const preferredAffinityWeight = 100
+
+func mergeNodeSelector(existing map[string]string, selector map[string]string, precedence ultron.SelectorPrecedence) (map[string]string, []string) {
+	merged := make(map[string]string, len(existing)+len(selector))
+	conflicts := []string{}
+
+	for key, value := range existing {
+		merged[key] = value
+	}
+
+	for key, value := range selector {
+		if existingValue, exists := existing[key]; exists && existingValue != value {
+			conflicts = append(conflicts, key)
+
+			if precedence != ultron.SelectorPrecedenceUltron {
+				continue
+			}
+		}
+
+		merged[key] = value
+	}
+
+	sort.Strings(conflicts)
+
+	return merged, conflicts
+}
+
+type placementCandidate struct {
+	selector map[string]string
+	weight   int32
+}
+
+func newPlacementCandidates(rankedNodes []ultron.RankedWeightedNode) []placementCandidate {
+	var candidates []placementCandidate
+
+	if len(rankedNodes) == 0 {
+		return candidates
+	}
+
+	highestScore := rankedNodes[0].TotalScore
+	lowestScore := rankedNodes[len(rankedNodes)-1].TotalScore
+
+	for _, rankedNode := range rankedNodes {
+		if len(rankedNode.Node.Selector) == 0 {
+			continue
+		}
+
+		weight := int32(preferredAffinityWeight)
+
+		if highestScore > lowestScore {
+			weight = 1 + int32(math.Round((preferredAffinityWeight-1)*(rankedNode.TotalScore-lowestScore)/(highestScore-lowestScore)))
+		}
+
+		candidates = append(candidates, placementCandidate{selector: rankedNode.Node.Selector, weight: weight})
+	}
+
+	return candidates
+}
+
+// withoutHostName drops the hostname from selectors patched into workload templates, which would
+// otherwise pin every replica to the single node that ranked best.
+func withoutHostName(candidates []placementCandidate) []placementCandidate {
+	workloadCandidates := make([]placementCandidate, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		selector := make(map[string]string, len(candidate.selector))
+
+		for key, value := range candidate.selector {
+			if key != ultron.LabelHostName {
+				selector[key] = value
+			}
+		}
+
+		workloadCandidates = append(workloadCandidates, placementCandidate{selector: selector, weight: candidate.weight})
+	}
+
+	return workloadCandidates
+}
+
+func newPlacementPatch(pod *corev1.Pod, specPath string, candidates []placementCandidate, mode ultron.PlacementMode, precedence ultron.SelectorPrecedence) ([]map[string]interface{}, []string) {
+	selectable := make([]placementCandidate, 0, len(candidates))
+
+	for _, candidate := range candidates {
+		if len(candidate.selector) > 0 {
+			selectable = append(selectable, candidate)
+		}
+	}
+
+	candidates = selectable
+
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	if mode != ultron.PlacementModePreferredAffinity {
+		candidates = candidates[:1]
+	}
+
+	var warnings []string
+	var conflicts []string
+
+	conflictingKeys := map[string]bool{}
+
+	for _, candidate := range candidates {
+		_, candidateConflicts := mergeNodeSelector(pod.Spec.NodeSelector, candidate.selector, precedence)
+
+		for _, key := range candidateConflicts {
+			if conflictingKeys[key] {
+				continue
+			}
+
+			conflictingKeys[key] = true
+			conflicts = append(conflicts, key)
+			warnings = append(warnings, fmt.Sprintf("ultron: node selector %s=%s conflicts with workload value %s", key, candidate.selector[key], pod.Spec.NodeSelector[key]))
+		}
+	}
+
+	if len(conflicts) > 0 && precedence == ultron.SelectorPrecedenceSkip {
+		return nil, append(warnings, "ultron: placement skipped due to node selector conflicts")
+	}
+
+	// Candidates whose every key is overridden by the workload would only add empty terms, and an
+	// empty required term matches no node at all.
+	var requirementSelectors []map[string]string
+	var weights []int32
+
+	for _, candidate := range candidates {
+		requirementSelector := make(map[string]string, len(candidate.selector))
+
+		for key, value := range candidate.selector {
+			if precedence == ultron.SelectorPrecedenceUltron || !conflictingKeys[key] {
+				requirementSelector[key] = value
+			}
+		}
+
+		if len(requirementSelector) > 0 {
+			requirementSelectors = append(requirementSelectors, requirementSelector)
+			weights = append(weights, candidate.weight)
+		}
+	}
+
+	if len(requirementSelectors) == 0 {
+		return nil, warnings
+	}
+
+	if mode != ultron.PlacementModeRequiredAffinity && mode != ultron.PlacementModePreferredAffinity {
+		merged, _ := mergeNodeSelector(pod.Spec.NodeSelector, candidates[0].selector, precedence)
+
+		return []map[string]interface{}{
+			{
+				"op":    "add",
+				"path":  specPath + "/nodeSelector",
+				"value": merged,
+			},
+		}, warnings
+	}
+
+	var patch []map[string]interface{}
+
+	if len(conflicts) > 0 && precedence == ultron.SelectorPrecedenceUltron {
+		nodeSelector := make(map[string]string, len(pod.Spec.NodeSelector))
+
+		for key, value := range pod.Spec.NodeSelector {
+			if !conflictingKeys[key] {
+				nodeSelector[key] = value
+			}
+		}
+
+		patch = append(patch, map[string]interface{}{
+			"op":    "add",
+			"path":  specPath + "/nodeSelector",
+			"value": nodeSelector,
+		})
+	}
+
+	affinity := &corev1.Affinity{}
+	if pod.Spec.Affinity != nil {
+		affinity = pod.Spec.Affinity.DeepCopy()
+	}
+
+	if affinity.NodeAffinity == nil {
+		affinity.NodeAffinity = &corev1.NodeAffinity{}
+	}
+
+	for i, requirementSelector := range requirementSelectors {
+		requirements := newNodeSelectorRequirements(requirementSelector)
+
+		if mode == ultron.PlacementModeRequiredAffinity {
+			required := affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+
+			if required == nil || len(required.NodeSelectorTerms) == 0 {
+				affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &corev1.NodeSelector{
+					NodeSelectorTerms: []corev1.NodeSelectorTerm{{MatchExpressions: requirements}},
+				}
+			} else {
+				for i := range required.NodeSelectorTerms {
+					required.NodeSelectorTerms[i].MatchExpressions = append(required.NodeSelectorTerms[i].MatchExpressions, requirements...)
+				}
+			}
+		} else {
+			affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(affinity.NodeAffinity.PreferredDuringSchedulingIgnoredDuringExecution, corev1.PreferredSchedulingTerm{
+				Weight:     weights[i],
+				Preference: corev1.NodeSelectorTerm{MatchExpressions: requirements},
+			})
+		}
+	}
+
+	patch = append(patch, map[string]interface{}{
+		"op":    "add",
+		"path":  specPath + "/affinity",
+		"value": affinity,
+	})
+
+	return patch, warnings
+}
+
+func newNodeSelectorRequirements(selector map[string]string) []corev1.NodeSelectorRequirement {
+	keys := make([]string, 0, len(selector))
+
+	for key := range selector {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	requirements := make([]corev1.NodeSelectorRequirement, 0, len(keys))
+
+	for _, key := range keys {
+		requirements = append(requirements, corev1.NodeSelectorRequirement{
+			Key:      key,
+			Operator: corev1.NodeSelectorOpIn,
+			Values:   []string{selector[key]},
+		})
+	}
+
+	return requirements
+}
@@ -3,39 +3,69 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"log"
+	"maps"
 	"net/http"
+	"strings"
+	"time"
 
 	ultron "github.com/be-heroes/ultron/pkg"
+	events "github.com/be-heroes/ultron/pkg/events"
 	mapper "github.com/be-heroes/ultron/pkg/mapper"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
 	services "github.com/be-heroes/ultron/pkg/services"
-	"github.com/redis/go-redis/v9"
+	tracing "github.com/be-heroes/ultron/pkg/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type IValidationHandler interface {
 	ValidatePodSpec(w http.ResponseWriter, r *http.Request)
-	HandleAdmissionReview(request *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error)
+	HandleAdmissionReview(ctx context.Context, request *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error)
+	DegradedStats() DegradedStats
 }
 
 type ValidationHandler struct {
-	computeService services.IComputeService
-	redisClient    *redis.Client
-	mapper         mapper.IMapper
+	computeService    services.IComputeService
+	kubernetesService services.IKubernetesService
+	eventBus          events.IEventBus
+	mapper            mapper.IMapper
+	enforce           bool
+	timeout           time.Duration
+	failurePolicy     ultron.FailurePolicy
+	degradedDecisions degradedDecisions
 }
 
-func NewValidationHandler(computeService services.IComputeService, mapper mapper.IMapper, redisClient *redis.Client) *ValidationHandler {
+func NewValidationHandler(computeService services.IComputeService, kubernetesService services.IKubernetesService, mapper mapper.IMapper, eventBus events.IEventBus, config *ultron.Config) *ValidationHandler {
+	if config == nil {
+		config = &ultron.Config{}
+	}
+
 	return &ValidationHandler{
-		computeService: computeService,
-		redisClient:    redisClient,
-		mapper:         mapper,
+		computeService:    computeService,
+		kubernetesService: kubernetesService,
+		eventBus:          eventBus,
+		mapper:            mapper,
+		enforce:           config.ValidationEnforce,
+		timeout:           newAdmissionTimeout(config),
+		failurePolicy:     newFailurePolicy(config),
 	}
 }
 
 func (vh *ValidationHandler) ValidatePodSpec(w http.ResponseWriter, r *http.Request) {
+	ctx, span := tracing.StartSpan(tracing.ExtractHttpContext(r), "ValidationHandler.ValidatePodSpec", trace.WithSpanKind(trace.SpanKindServer))
+	defer span.End()
+
+	ctx, cancel := newAdmissionContext(ctx, r, vh.timeout)
+	defer cancel()
+
 	var admissionReviewReq admissionv1.AdmissionReview
 	var admissionReviewResp admissionv1.AdmissionReview
 
@@ -54,7 +84,7 @@ func (vh *ValidationHandler) ValidatePodSpec(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	admissionResponse, err := vh.HandleAdmissionReview(admissionReviewReq.Request)
+	admissionResponse, err := vh.HandleAdmissionReview(ctx, admissionReviewReq.Request)
 	if err != nil {
 		log.Printf("Could not handle admission review: %v", err)
 		http.Error(w, "could not handle admission review", http.StatusInternalServerError)
@@ -84,38 +114,168 @@ func (vh *ValidationHandler) ValidatePodSpec(w http.ResponseWriter, r *http.Requ
 	}
 }
 
-func (vh *ValidationHandler) HandleAdmissionReview(request *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
-	if request.Kind.Kind != "Pod" {
-		return &admissionv1.AdmissionResponse{
-			Allowed: true,
-		}, nil
+func (vh *ValidationHandler) HandleAdmissionReview(ctx context.Context, request *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, error) {
+	start := time.Now()
+
+	admissionResponse, outcome := vh.admit(ctx, request)
+
+	metrics.ObserveAdmission(metrics.HandlerValidation, outcome, time.Since(start))
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("ultron.admission.outcome", outcome))
+
+	return admissionResponse, nil
+}
+
+func (vh *ValidationHandler) admit(ctx context.Context, request *admissionv1.AdmissionRequest) (*admissionv1.AdmissionResponse, string) {
+	template, err := decodePodTemplate(request)
+	if err != nil {
+		return vh.degradedDecisions.newResponse(ctx, vh.kubernetesService, newRequestPod(request), vh.failurePolicy, metrics.HandlerValidation, err)
 	}
 
-	var pod corev1.Pod
-	if err := json.Unmarshal(request.Object.Raw, &pod); err != nil {
+	if template == nil {
 		return &admissionv1.AdmissionResponse{
 			Allowed: true,
-		}, err
+		}, metrics.OutcomeSkipped
 	}
 
-	wNode, err := vh.computeService.MatchPodSpec(&pod)
+	admissionResponse, err := vh.validatePodTemplate(ctx, request, template)
+	if err != nil {
+		return vh.degradedDecisions.newResponse(ctx, vh.kubernetesService, &template.pod, vh.failurePolicy, metrics.HandlerValidation, err)
+	}
+
+	return admissionResponse, admissionOutcome(admissionResponse)
+}
+
+func (vh *ValidationHandler) DegradedStats() DegradedStats {
+	return vh.degradedDecisions.Stats()
+}
+
+func (vh *ValidationHandler) validatePodTemplate(ctx context.Context, request *admissionv1.AdmissionRequest, template *podTemplate) (*admissionv1.AdmissionResponse, error) {
+	pod := template.pod
+
+	violations, err := vh.computeService.ValidatePodSpec(ctx, &pod)
 	if err != nil {
 		return nil, err
 	}
 
-	if wNode == nil && vh.redisClient != nil {
-		wPod, err := vh.mapper.MapPodToWeightedPod(&pod)
+	var warnings []string
 
-		if err != nil {
-			return nil, err
-		}
+	for _, violation := range violations {
+		warnings = append(warnings, formatConstraintViolation(violation))
+	}
+
+	if len(violations) > 0 && vh.enforce {
+		log.Printf("Denying pod %s/%s: %s", pod.Namespace, pod.Name, strings.Join(warnings, "; "))
+
+		return &admissionv1.AdmissionResponse{
+			Allowed:  false,
+			Result:   newConstraintViolationStatus(request.Kind.Kind, &pod, violations),
+			Warnings: warnings,
+		}, nil
+	}
+
+	wNode, err := vh.computeService.MatchPodSpec(ctx, &pod)
+	if err != nil {
+		return nil, err
+	}
 
-		vh.redisClient.Publish(context.Background(), ultron.TopicPodObserve, wPod)
-	} else if vh.redisClient != nil {
-		vh.redisClient.Publish(context.Background(), ultron.TopicNodeObserve, wNode)
+	if vh.eventBus != nil && (request.DryRun == nil || !*request.DryRun) {
+		vh.publishObserveEvent(ctx, request, &pod, wNode)
 	}
 
 	return &admissionv1.AdmissionResponse{
-		Allowed: true,
+		Allowed:  true,
+		Warnings: warnings,
 	}, nil
 }
+
+func (vh *ValidationHandler) publishObserveEvent(ctx context.Context, request *admissionv1.AdmissionRequest, pod *corev1.Pod, wNode *ultron.WeightedNode) {
+	wPod, err := vh.mapper.MapPodToWeightedPod(pod)
+	if err != nil {
+		log.Printf("Could not map pod %s/%s to weighted pod: %v", pod.Namespace, pod.Name, err)
+
+		return
+	}
+
+	data := events.ObserveEventData{
+		AdmissionUid: string(request.UID),
+		Kind:         request.Kind.Kind,
+		Namespace:    pod.Namespace,
+		Name:         pod.Name,
+		Pod:          &wPod,
+	}
+
+	// Pods only matched to a compute configuration need a node provisioned before they can land.
+	if wNode == nil || len(wNode.Selector) == 0 || wNode.Selector[ultron.LabelComputeType] != "" {
+		vh.publish(ctx, ultron.TopicPodObserve, events.NewObserveEvent(events.EventTypePodObserved, data))
+
+		return
+	}
+
+	data.Node = wNode
+	data.Scores = vh.explainWeightedNode(ctx, pod, wNode)
+
+	vh.publish(ctx, ultron.TopicNodeObserve, events.NewObserveEvent(events.EventTypeNodeObserved, data))
+}
+
+func (vh *ValidationHandler) publish(ctx context.Context, topic string, event *events.ObserveEvent) {
+	if err := vh.eventBus.Publish(ctx, topic, event); err != nil {
+		log.Printf("Could not publish %s event: %v", topic, err)
+	}
+}
+
+func (vh *ValidationHandler) explainWeightedNode(ctx context.Context, pod *corev1.Pod, wNode *ultron.WeightedNode) *ultron.ScoreExplanation {
+	candidates, err := vh.computeService.ExplainPodSpec(ctx, pod)
+	if err != nil {
+		return nil
+	}
+
+	for _, candidate := range candidates {
+		if maps.Equal(candidate.Selector, wNode.Selector) {
+			return &candidate.Explanation
+		}
+	}
+
+	return nil
+}
+
+func formatConstraintViolation(violation ultron.ConstraintViolation) string {
+	if violation.Annotation == "" {
+		return fmt.Sprintf("ultron: %s", violation.Reason)
+	}
+
+	return fmt.Sprintf("ultron: %s=%q %s", violation.Annotation, violation.Value, violation.Reason)
+}
+
+func newConstraintViolationStatus(kind string, pod *corev1.Pod, violations []ultron.ConstraintViolation) *metav1.Status {
+	causes := make([]metav1.StatusCause, 0, len(violations))
+
+	for _, violation := range violations {
+		cause := metav1.StatusCause{
+			Type:    metav1.CauseTypeFieldValueNotFound,
+			Message: violation.Reason,
+			Field:   "metadata.annotations",
+		}
+
+		if violation.Malformed {
+			cause.Type = metav1.CauseTypeFieldValueInvalid
+		}
+
+		if violation.Annotation != "" {
+			cause.Field = fmt.Sprintf("metadata.annotations[%s]", violation.Annotation)
+		}
+
+		causes = append(causes, cause)
+	}
+
+	return &metav1.Status{
+		Status:  metav1.StatusFailure,
+		Message: fmt.Sprintf("%s %s violates %d ultron placement constraint(s)", strings.ToLower(kind), pod.Name, len(violations)),
+		Reason:  metav1.StatusReasonInvalid,
+		Code:    http.StatusUnprocessableEntity,
+		Details: &metav1.StatusDetails{
+			Name:   pod.Name,
+			Kind:   kind,
+			Causes: causes,
+		},
+	}
+}
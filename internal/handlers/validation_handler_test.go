@@ -2,20 +2,34 @@ package handlers_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	handlers "github.com/be-heroes/ultron/internal/handlers"
 	"github.com/be-heroes/ultron/mocks" // Import the generated mocks
 	ultron "github.com/be-heroes/ultron/pkg"
+	algorithm "github.com/be-heroes/ultron/pkg/algorithm"
+	events "github.com/be-heroes/ultron/pkg/events"
+	mapper "github.com/be-heroes/ultron/pkg/mapper"
+	provisioners "github.com/be-heroes/ultron/pkg/provisioners"
+	services "github.com/be-heroes/ultron/pkg/services"
+	subscribers "github.com/be-heroes/ultron/pkg/subscribers"
 
 	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -25,12 +39,13 @@ func TestValidatePods_Success(t *testing.T) {
 	mockComputeService := new(mocks.IComputeService)
 	mockMapper := new(mocks.IMapper)
 
-	mockComputeService.On("MatchPodSpec", mock.AnythingOfType("*v1.Pod")).
+	mockComputeService.On("ValidatePodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return([]ultron.ConstraintViolation{}, nil)
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).
 		Return(&ultron.WeightedNode{
 			Selector: map[string]string{"node-type": "mock-node"},
 		}, nil)
 
-	handler := handlers.NewValidationHandler(mockComputeService, mockMapper, nil)
+	handler := handlers.NewValidationHandler(mockComputeService, nil, mockMapper, nil, nil)
 
 	pod := corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -72,7 +87,7 @@ func TestValidatePods_InvalidBody(t *testing.T) {
 	mockComputeService := new(mocks.IComputeService)
 	mockMapper := new(mocks.IMapper)
 
-	handler := handlers.NewValidationHandler(mockComputeService, mockMapper, nil)
+	handler := handlers.NewValidationHandler(mockComputeService, nil, mockMapper, nil, nil)
 
 	req := httptest.NewRequest(http.MethodPost, "/validate", bytes.NewBuffer([]byte("invalid body")))
 	w := httptest.NewRecorder()
@@ -87,13 +102,13 @@ func TestValidationHandleAdmissionReview_NonPodKind(t *testing.T) {
 	mockComputeService := new(mocks.IComputeService)
 	mockMapper := new(mocks.IMapper)
 
-	handler := handlers.NewValidationHandler(mockComputeService, mockMapper, nil)
+	handler := handlers.NewValidationHandler(mockComputeService, nil, mockMapper, nil, nil)
 
 	admissionRequest := &admissionv1.AdmissionRequest{
 		Kind: metav1.GroupVersionKind{Kind: "Service"},
 	}
 
-	admissionResponse, err := handler.HandleAdmissionReview(admissionRequest)
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), admissionRequest)
 	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
 	assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true for non-pod kind")
 }
@@ -102,9 +117,10 @@ func TestValidationHandleAdmissionReview_PodSpecFailure(t *testing.T) {
 	mockComputeService := new(mocks.IComputeService)
 	mockMapper := new(mocks.IMapper)
 
-	mockComputeService.On("MatchPodSpec", mock.AnythingOfType("*v1.Pod")).Return(nil, nil)
+	mockComputeService.On("ValidatePodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return([]ultron.ConstraintViolation{}, nil)
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return(nil, nil)
 
-	handler := handlers.NewValidationHandler(mockComputeService, mockMapper, nil)
+	handler := handlers.NewValidationHandler(mockComputeService, nil, mockMapper, nil, nil)
 
 	pod := corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
@@ -122,7 +138,311 @@ func TestValidationHandleAdmissionReview_PodSpecFailure(t *testing.T) {
 		},
 	}
 
-	admissionResponse, err := handler.HandleAdmissionReview(admissionRequest)
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), admissionRequest)
 	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
 	assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true")
 }
+
+func newValidationAdmissionRequest() *admissionv1.AdmissionRequest {
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Annotations: map[string]string{ultron.AnnotationDiskType: "NVMe"},
+		},
+	}
+	rawPod, _ := json.Marshal(pod)
+
+	return &admissionv1.AdmissionRequest{
+		UID:  "1234",
+		Kind: metav1.GroupVersionKind{Kind: "Pod"},
+		Object: runtime.RawExtension{
+			Raw: rawPod,
+		},
+	}
+}
+
+func TestValidationHandleAdmissionReview_EnforceDeniesViolations(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	mockMapper := new(mocks.IMapper)
+
+	mockComputeService.On("ValidatePodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return([]ultron.ConstraintViolation{
+		{Annotation: ultron.AnnotationDiskType, Value: "NVMe", Reason: "no node or compute configuration provides this disk type"},
+	}, nil)
+
+	handler := handlers.NewValidationHandler(mockComputeService, nil, mockMapper, nil, &ultron.Config{ValidationEnforce: true})
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newValidationAdmissionRequest())
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.False(t, admissionResponse.Allowed, "Expected Allowed to be false")
+	assert.Equal(t, int32(http.StatusUnprocessableEntity), admissionResponse.Result.Code)
+	assert.Equal(t, metav1.StatusReasonInvalid, admissionResponse.Result.Reason)
+	assert.Len(t, admissionResponse.Result.Details.Causes, 1)
+	assert.Equal(t, "metadata.annotations[ultron.io/disk-type]", admissionResponse.Result.Details.Causes[0].Field)
+	assert.Equal(t, metav1.CauseTypeFieldValueNotFound, admissionResponse.Result.Details.Causes[0].Type)
+	assert.Equal(t, []string{`ultron: ultron.io/disk-type="NVMe" no node or compute configuration provides this disk type`}, admissionResponse.Warnings)
+	mockComputeService.AssertNotCalled(t, "MatchPodSpec", mock.Anything, mock.Anything)
+}
+
+func TestValidationHandleAdmissionReview_AuditWarnsOnViolations(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	mockMapper := new(mocks.IMapper)
+
+	mockComputeService.On("ValidatePodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return([]ultron.ConstraintViolation{
+		{Annotation: ultron.AnnotationStorageSizeGb, Value: "abc", Malformed: true, Reason: "must be a positive number"},
+	}, nil)
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return(nil, nil)
+
+	handler := handlers.NewValidationHandler(mockComputeService, nil, mockMapper, nil, nil)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), newValidationAdmissionRequest())
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true when not enforcing")
+	assert.Len(t, admissionResponse.Warnings, 1, "Expected violations to surface as warnings")
+}
+
+func TestValidationHandleAdmissionReview_Job(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	mockMapper := new(mocks.IMapper)
+
+	mockComputeService.On("ValidatePodSpec", mock.Anything, mock.MatchedBy(func(pod *corev1.Pod) bool {
+		return pod.Name == "test-job" && pod.Namespace == "batch" && pod.Annotations[ultron.AnnotationDiskType] == "NVMe"
+	})).Return([]ultron.ConstraintViolation{
+		{Annotation: ultron.AnnotationDiskType, Value: "NVMe", Reason: "no node or compute configuration provides this disk type"},
+	}, nil)
+
+	handler := handlers.NewValidationHandler(mockComputeService, nil, mockMapper, nil, &ultron.Config{ValidationEnforce: true})
+
+	job := batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-job",
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{ultron.AnnotationDiskType: "NVMe"},
+				},
+			},
+		},
+	}
+	rawJob, _ := json.Marshal(job)
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), &admissionv1.AdmissionRequest{
+		UID:       "1234",
+		Kind:      metav1.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"},
+		Namespace: "batch",
+		Object:    runtime.RawExtension{Raw: rawJob},
+	})
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.False(t, admissionResponse.Allowed, "Expected the job template to be validated")
+	assert.Equal(t, "Job", admissionResponse.Result.Details.Kind, "Expected the status to name the workload kind")
+}
+
+func TestValidationHandleAdmissionReview_DaemonSet(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	mockMapper := new(mocks.IMapper)
+
+	mockComputeService.On("ValidatePodSpec", mock.Anything, mock.MatchedBy(func(pod *corev1.Pod) bool {
+		return pod.Name == "test-daemonset" && pod.Annotations[ultron.AnnotationDiskType] == "NVMe"
+	})).Return([]ultron.ConstraintViolation{
+		{Annotation: ultron.AnnotationDiskType, Value: "NVMe", Reason: "no node or compute configuration provides this disk type"},
+	}, nil)
+
+	handler := handlers.NewValidationHandler(mockComputeService, nil, mockMapper, nil, &ultron.Config{ValidationEnforce: true})
+
+	rawDaemonSet, _ := json.Marshal(appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-daemonset", Namespace: "default"},
+		Spec: appsv1.DaemonSetSpec{
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ultron.AnnotationDiskType: "NVMe"}},
+			},
+		},
+	})
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), &admissionv1.AdmissionRequest{
+		UID:    "1234",
+		Kind:   metav1.GroupVersionKind{Group: "apps", Version: "v1", Kind: "DaemonSet"},
+		Object: runtime.RawExtension{Raw: rawDaemonSet},
+	})
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.False(t, admissionResponse.Allowed, "Expected the DaemonSet template to be validated")
+	assert.Equal(t, "DaemonSet", admissionResponse.Result.Details.Kind)
+}
+
+func TestValidationHandleAdmissionReview_PublishesNodeObserveEvent(t *testing.T) {
+	eventBus := events.NewInMemoryEventBus(0)
+	mockComputeService := new(mocks.IComputeService)
+	mockMapper := new(mocks.IMapper)
+
+	wNode := &ultron.WeightedNode{Selector: map[string]string{ultron.LabelHostName: "node1"}}
+
+	mockComputeService.On("ValidatePodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return([]ultron.ConstraintViolation{}, nil)
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return(wNode, nil)
+	mockComputeService.On("ExplainPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return([]ultron.CandidateExplanation{
+		{Rank: 1, Selector: wNode.Selector, Eligible: true, Explanation: ultron.ScoreExplanation{TotalScore: 2.5}},
+	}, nil)
+	mockMapper.On("MapPodToWeightedPod", mock.AnythingOfType("*v1.Pod")).Return(ultron.WeightedPod{
+		Selector: map[string]string{ultron.MetadataName: "test-pod"},
+	}, nil)
+
+	handler := handlers.NewValidationHandler(mockComputeService, nil, mockMapper, eventBus, nil)
+
+	pod := corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod"}}
+	rawPod, _ := json.Marshal(pod)
+
+	_, err := handler.HandleAdmissionReview(context.Background(), &admissionv1.AdmissionRequest{
+		UID:       "1234",
+		Kind:      metav1.GroupVersionKind{Kind: "Pod"},
+		Namespace: "default",
+		Object:    runtime.RawExtension{Raw: rawPod},
+	})
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+
+	var published []*events.ObserveEvent
+	err = eventBus.Replay(context.Background(), ultron.TopicNodeObserve, time.Time{}, func(ctx context.Context, event *events.ObserveEvent) error {
+		published = append(published, event)
+
+		return nil
+	})
+	assert.NoError(t, err, "Replay should not return an error")
+	assert.Len(t, published, 1, "Expected a node observe event to be published")
+	assert.Equal(t, events.EventTypeNodeObserved, published[0].Type)
+	assert.Equal(t, "1234", published[0].Data.AdmissionUid)
+	assert.Equal(t, "default/test-pod", published[0].Subject)
+	assert.Equal(t, "node1", published[0].Data.Node.Selector[ultron.LabelHostName])
+	assert.Equal(t, 2.5, published[0].Data.Scores.TotalScore)
+}
+
+func TestValidationHandleAdmissionReview_DryRunSkipsObserveEvents(t *testing.T) {
+	eventBus := events.NewInMemoryEventBus(0)
+	mockComputeService := new(mocks.IComputeService)
+	mockMapper := new(mocks.IMapper)
+
+	mockComputeService.On("ValidatePodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return([]ultron.ConstraintViolation{}, nil)
+	mockComputeService.On("MatchPodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return(&ultron.WeightedNode{
+		Selector: map[string]string{ultron.LabelHostName: "node1"},
+	}, nil)
+
+	handler := handlers.NewValidationHandler(mockComputeService, nil, mockMapper, eventBus, nil)
+
+	dryRun := true
+	request := newValidationAdmissionRequest()
+	request.DryRun = &dryRun
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), request)
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.True(t, admissionResponse.Allowed, "Expected dry-run requests to be allowed")
+
+	var published []*events.ObserveEvent
+	err = eventBus.Replay(context.Background(), ultron.TopicNodeObserve, time.Time{}, func(ctx context.Context, event *events.ObserveEvent) error {
+		published = append(published, event)
+
+		return nil
+	})
+	assert.NoError(t, err, "Replay should not return an error")
+	assert.Empty(t, published, "Expected no observe events for a dry-run request")
+	mockMapper.AssertNotCalled(t, "MapPodToWeightedPod", mock.Anything)
+}
+
+func TestValidationHandleAdmissionReview_ValidateFailureFailsClosed(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	mockMapper := new(mocks.IMapper)
+
+	mockComputeService.On("ValidatePodSpec", mock.Anything, mock.AnythingOfType("*v1.Pod")).Return(nil, errors.New("key not found"))
+
+	handler := handlers.NewValidationHandler(mockComputeService, nil, mockMapper, nil, &ultron.Config{AdmissionFailurePolicy: ultron.FailurePolicyClosed})
+
+	pod := corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-pod",
+		},
+	}
+	rawPod, _ := json.Marshal(pod)
+
+	admissionRequest := &admissionv1.AdmissionRequest{
+		UID:  "1234",
+		Kind: metav1.GroupVersionKind{Kind: "Pod"},
+		Object: runtime.RawExtension{
+			Raw: rawPod,
+		},
+	}
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), admissionRequest)
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.False(t, admissionResponse.Allowed, "Expected Allowed to be false when failing closed")
+	assert.Equal(t, metav1.StatusReasonInternalError, admissionResponse.Result.Reason, "Expected an internal error status")
+	assert.Equal(t, handlers.DegradedStats{FailedClosed: 1}, handler.DegradedStats(), "Expected the degraded decision to be counted")
+}
+
+func TestValidationHandleAdmissionReview_MalformedObjectFailsOpen(t *testing.T) {
+	mockComputeService := new(mocks.IComputeService)
+	mockMapper := new(mocks.IMapper)
+
+	handler := handlers.NewValidationHandler(mockComputeService, nil, mockMapper, nil, nil)
+
+	admissionRequest := &admissionv1.AdmissionRequest{
+		UID:  "1234",
+		Kind: metav1.GroupVersionKind{Kind: "Pod"},
+		Object: runtime.RawExtension{
+			Raw: []byte("invalid pod"),
+		},
+	}
+
+	admissionResponse, err := handler.HandleAdmissionReview(context.Background(), admissionRequest)
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true when failing open")
+	assert.Len(t, admissionResponse.Warnings, 1, "Expected a degraded decision warning")
+	assert.Equal(t, handlers.DegradedStats{FailedOpen: 1}, handler.DegradedStats(), "Expected the degraded decision to be counted")
+}
+
+func TestValidationHandleAdmissionReview_ProvisionsComputeConfigurationFallback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	cacheService := services.NewCacheService(nil, nil, 0)
+	_ = cacheService.AddCacheItem(ctx, ultron.CacheKeyWeightedNodes, []ultron.WeightedNode{}, 0)
+	_ = cacheService.AddCacheItem(ctx, ultron.CacheKeyEphemeralComputeConfigurations, []ultron.ComputeConfiguration{}, 0)
+	_ = cacheService.AddCacheItem(ctx, ultron.CacheKeyDurableComputeConfigurations, []ultron.ComputeConfiguration{{
+		Identifier:        stringPtr("t3.medium"),
+		ComputeType:       ultron.ComputeTypeDurable,
+		VCpu:              int64Ptr(2),
+		RamGb:             int64Ptr(4),
+		VolumeGb:          int64Ptr(50),
+		VolumeType:        stringPtr(ultron.DefaultDiskType),
+		CloudNetworkTypes: []string{ultron.DefaultNetworkType},
+		Cost:              &ultron.ComputeCost{PricePerUnit: float64Ptr(0.04)},
+	}}, 0)
+
+	weightProfileStore, _ := algorithm.NewWeightProfileStore("")
+	podMapper := mapper.NewMapper()
+	computeService := services.NewComputeService(algorithm.NewAlgorithm(weightProfileStore), cacheService, podMapper)
+	eventBus := events.NewInMemoryEventBus(0)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		provisioners.NodePoolResource: "NodePoolList",
+	})
+	provisioner := provisioners.NewKarpenterProvisioner(dynamicClient, provisioners.KarpenterNodeClassRef{Name: "default"})
+
+	assert.NoError(t, subscribers.NewPodObserveSubscriber(eventBus, computeService, provisioner).Start(ctx))
+
+	handler := handlers.NewValidationHandler(computeService, nil, podMapper, eventBus, nil)
+
+	rawPod, _ := json.Marshal(corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"}})
+
+	admissionResponse, err := handler.HandleAdmissionReview(ctx, &admissionv1.AdmissionRequest{
+		UID:    "1234",
+		Kind:   metav1.GroupVersionKind{Kind: "Pod"},
+		Object: runtime.RawExtension{Raw: rawPod},
+	})
+	assert.NoError(t, err, "HandleAdmissionReview should not return an error")
+	assert.True(t, admissionResponse.Allowed, "Expected Allowed to be true")
+
+	assert.Eventually(t, func() bool {
+		nodePool, err := dynamicClient.Resource(provisioners.NodePoolResource).Get(ctx, "ultron-durable", metav1.GetOptions{})
+		if err != nil {
+			return false
+		}
+
+		computeType, _, _ := unstructured.NestedString(nodePool.Object, "spec", "template", "metadata", "labels", ultron.LabelComputeType)
+
+		return computeType == string(ultron.ComputeTypeDurable)
+	}, 5*time.Second, 10*time.Millisecond, "Expected a node pool for the compute configuration fallback")
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type podTemplate struct {
+	pod            corev1.Pod
+	metadataPath   string
+	specPath       string
+	workload       bool
+	validationOnly bool
+}
+
+func decodePodTemplate(request *admissionv1.AdmissionRequest) (*podTemplate, error) {
+	if request.Kind.Kind == "Pod" {
+		var pod corev1.Pod
+		if err := json.Unmarshal(request.Object.Raw, &pod); err != nil {
+			return nil, err
+		}
+
+		if pod.Namespace == "" {
+			pod.Namespace = request.Namespace
+		}
+
+		return &podTemplate{pod: pod, metadataPath: "/metadata", specPath: "/spec"}, nil
+	}
+
+	objectMeta, template, templatePath, err := decodeWorkload(request.Kind.Kind, request.Object.Raw)
+	if err != nil || template == nil {
+		return nil, err
+	}
+
+	pod := corev1.Pod{
+		ObjectMeta: *template.ObjectMeta.DeepCopy(),
+		Spec:       *template.Spec.DeepCopy(),
+	}
+
+	if pod.Name == "" {
+		pod.Name = objectMeta.Name
+	}
+
+	if pod.Name == "" {
+		pod.Name = objectMeta.GenerateName
+	}
+
+	pod.Namespace = objectMeta.Namespace
+
+	if pod.Namespace == "" {
+		pod.Namespace = request.Namespace
+	}
+
+	return &podTemplate{
+		pod:            pod,
+		metadataPath:   templatePath + "/metadata",
+		specPath:       templatePath + "/spec",
+		workload:       true,
+		validationOnly: request.Kind.Kind == "DaemonSet",
+	}, nil
+}
+
+// workloadTemplateUnchanged reports updates that leave the pod template spec as it was, such as
+// scaling, which must not trigger a new placement.
+func workloadTemplateUnchanged(request *admissionv1.AdmissionRequest) bool {
+	if request.Operation != admissionv1.Update || len(request.OldObject.Raw) == 0 {
+		return false
+	}
+
+	_, oldTemplate, _, err := decodeWorkload(request.Kind.Kind, request.OldObject.Raw)
+	if err != nil || oldTemplate == nil {
+		return false
+	}
+
+	_, template, _, err := decodeWorkload(request.Kind.Kind, request.Object.Raw)
+	if err != nil || template == nil {
+		return false
+	}
+
+	return equality.Semantic.DeepEqual(oldTemplate.Spec, template.Spec)
+}
+
+// decodeWorkload extracts the pod template of a workload kind. DaemonSets run on every eligible node,
+// so their templates are only validated and never narrowed to a placement.
+func decodeWorkload(kind string, raw []byte) (*metav1.ObjectMeta, *corev1.PodTemplateSpec, string, error) {
+	switch kind {
+	case "Deployment":
+		var deployment appsv1.Deployment
+		if err := json.Unmarshal(raw, &deployment); err != nil {
+			return nil, nil, "", err
+		}
+
+		return &deployment.ObjectMeta, &deployment.Spec.Template, "/spec/template", nil
+	case "DaemonSet":
+		var daemonSet appsv1.DaemonSet
+		if err := json.Unmarshal(raw, &daemonSet); err != nil {
+			return nil, nil, "", err
+		}
+
+		return &daemonSet.ObjectMeta, &daemonSet.Spec.Template, "/spec/template", nil
+	case "StatefulSet":
+		var statefulSet appsv1.StatefulSet
+		if err := json.Unmarshal(raw, &statefulSet); err != nil {
+			return nil, nil, "", err
+		}
+
+		return &statefulSet.ObjectMeta, &statefulSet.Spec.Template, "/spec/template", nil
+	case "ReplicaSet":
+		var replicaSet appsv1.ReplicaSet
+		if err := json.Unmarshal(raw, &replicaSet); err != nil {
+			return nil, nil, "", err
+		}
+
+		return &replicaSet.ObjectMeta, &replicaSet.Spec.Template, "/spec/template", nil
+	case "Job":
+		var job batchv1.Job
+		if err := json.Unmarshal(raw, &job); err != nil {
+			return nil, nil, "", err
+		}
+
+		return &job.ObjectMeta, &job.Spec.Template, "/spec/template", nil
+	case "CronJob":
+		var cronJob batchv1.CronJob
+		if err := json.Unmarshal(raw, &cronJob); err != nil {
+			return nil, nil, "", err
+		}
+
+		return &cronJob.ObjectMeta, &cronJob.Spec.JobTemplate.Spec.Template, "/spec/jobTemplate/spec/template", nil
+	default:
+		return nil, nil, "", nil
+	}
+}
@@ -2,17 +2,30 @@ package main
 
 import (
 	"context"
-	"crypto/tls"
+	"errors"
 	"net/http"
-	"strings"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/patrickmn/go-cache"
 	"go.uber.org/zap"
 
 	handlers "github.com/be-heroes/ultron/internal/handlers"
 	ultron "github.com/be-heroes/ultron/pkg"
 	algorithm "github.com/be-heroes/ultron/pkg/algorithm"
+	events "github.com/be-heroes/ultron/pkg/events"
 	mapper "github.com/be-heroes/ultron/pkg/mapper"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+	observers "github.com/be-heroes/ultron/pkg/observers"
+	provisioners "github.com/be-heroes/ultron/pkg/provisioners"
 	services "github.com/be-heroes/ultron/pkg/services"
+	sources "github.com/be-heroes/ultron/pkg/sources"
+	subscribers "github.com/be-heroes/ultron/pkg/subscribers"
+	tracing "github.com/be-heroes/ultron/pkg/tracing"
+
+	"k8s.io/client-go/dynamic"
 )
 
 func main() {
@@ -27,58 +40,246 @@ func main() {
 		sugar.Fatalf("Failed to load Ultron configuration: %v", err)
 	}
 
-	ctx := context.Background()
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	shutdownTracerProvider, err := tracing.NewTracerProvider(ctx, config)
+	if err != nil {
+		sugar.Fatalf("Failed to initialize tracing: %v", err)
+	}
+
 	redisClient := ultron.InitializeRedisClientFromConfig(ctx, config, sugar)
+	redisClient.AddHook(tracing.NewRedisHook())
 	mapper := mapper.NewMapper()
-	algorithm := algorithm.NewAlgorithm()
-	cacheService := services.NewCacheService(nil, redisClient)
+	weightProfileStore, err := algorithm.NewWeightProfileStore("")
+	if err != nil {
+		sugar.Fatalf("Failed to initialize weight profiles: %v", err)
+	}
+
+	if config.WeightProfilesPath != "" {
+		if err := weightProfileStore.Load(config.WeightProfilesPath); err != nil {
+			sugar.Fatalf("Failed to load weight profiles: %v", err)
+		}
+	}
+
+	if config.WeightProfile != "" {
+		if err := weightProfileStore.SetDefaultProfile(config.WeightProfile); err != nil {
+			sugar.Fatalf("Failed to set default weight profile: %v", err)
+		}
+	}
+
+	algorithm := algorithm.NewAlgorithm(weightProfileStore)
+	cacheService := services.NewCacheService(cache.New(config.LocalCacheTtl, config.LocalCacheTtl), redisClient, config.LocalCacheTtl)
 	certificateService := services.NewCertificateService()
 	computeService := services.NewComputeService(algorithm, cacheService, mapper)
-	mutationHandler := handlers.NewMutationHandler(computeService)
-	validationHandler := handlers.NewValidationHandler(computeService, mapper, redisClient)
+	kubernetesConfig, err := services.NewKubernetesConfig(config.KubernetesMasterUrl, config.KubernetesConfigPath, false)
+	if err != nil {
+		sugar.Fatalf("Failed to load Kubernetes configuration: %v", err)
+	}
 
-	sugar.Info("Initialized Ultron")
-	sugar.Info("Generating self-signed certificate")
-
-	cert, err := certificateService.GenerateSelfSignedCert(
-		config.CertificateOrganization,
-		config.CertificateCommonName,
-		strings.Split(config.CertificateDnsNamesCSV, ","),
-		ultron.ParseCsvIpAddressString(config.CertificateIpAddressesCSV),
-	)
+	kubernetesService, err := services.NewInformerKubernetesServiceFromConfig(kubernetesConfig, config.InformerResyncPeriod, config.MetricsPollInterval)
+	if err != nil {
+		sugar.Fatalf("Failed to initialize Kubernetes service: %v", err)
+	}
+
+	nodeObserver := observers.NewNodeObserver(kubernetesService, computeService, cacheService, mapper)
+	kubernetesService.AddEventHandler(nodeObserver.HandleClusterEvent)
+	kubernetesService.AddEventHandler(weightProfileStore.HandleClusterEvent)
+
+	shadowObserver := observers.NewShadowObserver(kubernetesService)
+	kubernetesService.AddEventHandler(shadowObserver.HandleClusterEvent)
+
+	hostname, err := os.Hostname()
 	if err != nil {
-		sugar.Fatalf("Failed to generate self-signed certificate: %v", err)
+		sugar.Fatalf("Failed to resolve hostname: %v", err)
 	}
 
-	sugar.Info("Generated self-signed certificate")
+	// Hostnames change across pod restarts, so messages left pending by a previous consumer are
+	// reclaimed by whichever replica finds them idle.
+	eventBus := events.NewRedisStreamEventBus(redisClient, config.EventBusMaxLen, hostname)
+	mutationHandler := handlers.NewMutationHandler(computeService, kubernetesService, config)
+	validationHandler := handlers.NewValidationHandler(computeService, kubernetesService, mapper, eventBus, config)
+	explainHandler := handlers.NewExplainHandler(computeService)
+
+	computeConfigurationSource, err := sources.NewComputeConfigurationSourceFromConfig(config, kubernetesConfig)
+	if err != nil {
+		sugar.Fatalf("Failed to initialize compute configuration source: %v", err)
+	}
 
-	if config.CertificateExportPath != "" {
-		sugar.Info("Exporting CA certificate to path: %s", config.CertificateExportPath)
+	sugar.Info("Initialized Ultron")
 
-		err = certificateService.ExportCACert(cert.Certificate[0], config.CertificateExportPath)
+	certificateSource, err := services.NewCertificateSourceFromConfig(config, kubernetesConfig, certificateService)
+	if err != nil {
+		sugar.Fatalf("Failed to initialize certificate source: %v", err)
+	}
+
+	sugar.Infof("Loading serving certificate from %s source", config.CertificateSource)
+
+	if config.WebhookRegistrationEnabled {
+		webhookRegistrar, err := services.NewWebhookRegistrarFromConfig(config, kubernetesConfig)
 		if err != nil {
-			sugar.Fatalf("Failed to export CA certificate to file: %v", err)
+			sugar.Fatalf("Failed to initialize webhook registrar: %v", err)
 		}
 
-		sugar.Info("Exported CA certificate")
+		certificateSource.AddRotationHandler(func(ctx context.Context, caBundle []byte) error {
+			if err := webhookRegistrar.Register(ctx, caBundle); err != nil {
+				return err
+			}
+
+			sugar.Infof("Registered webhook configurations %s with the current CA bundle", config.WebhookConfigurationName)
+
+			return nil
+		})
 	}
 
+	if err := certificateSource.Rotate(ctx); err != nil {
+		sugar.Fatalf("Failed to load serving certificate: %v", err)
+	}
+
+	sugar.Info("Loaded serving certificate")
+
+	clientCa, err := services.NewClientCaFromConfig(config, kubernetesConfig)
+	if err != nil {
+		sugar.Fatalf("Failed to initialize client CA: %v", err)
+	}
+
+	mutatePodSpec := mutationHandler.MutatePodSpec
+	validatePodSpec := validationHandler.ValidatePodSpec
+	explainPodSpec := explainHandler.ExplainPodSpec
+
+	if clientCa != nil {
+		sugar.Infof("Loading client CA from %s source: %s", config.ClientCaSource, config.ClientCaLocation)
+
+		if err := clientCa.Load(ctx); err != nil {
+			sugar.Fatalf("Failed to load client CA: %v", err)
+		}
+
+		go func() {
+			if err := clientCa.Start(ctx, config.ClientCaReloadInterval); err != nil && err != context.Canceled {
+				sugar.Errorf("Client CA loader stopped: %v", err)
+			}
+		}()
+
+		clientAuthHandler := handlers.NewClientAuthHandler(config)
+		mutatePodSpec = clientAuthHandler.Wrap(mutatePodSpec)
+		validatePodSpec = clientAuthHandler.Wrap(validatePodSpec)
+		explainPodSpec = clientAuthHandler.Wrap(explainPodSpec)
+	}
+
+	healthHandler := handlers.NewHealthHandler(cacheService, redisClient, certificateSource.Certificate, config)
+
 	mux := http.NewServeMux()
-	mux.HandleFunc("/mutate", mutationHandler.MutatePodSpec)
-	mux.HandleFunc("/validate", validationHandler.ValidatePodSpec)
-	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	mux.HandleFunc("/mutate", mutatePodSpec)
+	mux.HandleFunc("/validate", validatePodSpec)
+	mux.HandleFunc("/explain", explainPodSpec)
+	mux.HandleFunc("/healthz", healthHandler.Livez)
+	mux.HandleFunc("/livez", healthHandler.Livez)
+	mux.HandleFunc("/readyz", healthHandler.Readyz)
+	mux.Handle("/metrics", metrics.Handler())
+
+	if err := cacheService.StartInvalidationListener(ctx); err != nil {
+		sugar.Fatalf("Failed to subscribe to cache invalidations: %v", err)
+	}
+
+	sugar.Info("Starting Kubernetes informers")
+
+	if err := kubernetesService.Start(ctx); err != nil {
+		sugar.Fatalf("Failed to start Kubernetes informers: %v", err)
+	}
+
+	sugar.Info("Started Kubernetes informers")
+
+	if computeConfigurationSource != nil {
+		computeConfigurationLoader := sources.NewComputeConfigurationLoader(computeConfigurationSource, cacheService)
+
+		sugar.Infof("Loading compute configurations from %s source: %s", config.ComputeConfigurationSource, config.ComputeConfigurationLocation)
+
+		if err := computeConfigurationLoader.Load(ctx); err != nil {
+			sugar.Errorf("Failed to load compute configurations: %v", err)
+		}
+
+		go func() {
+			if err := computeConfigurationLoader.Start(ctx, config.ComputeConfigurationReloadInterval); err != nil && err != context.Canceled {
+				sugar.Errorf("Compute configuration loader stopped: %v", err)
+			}
+		}()
+	}
+
+	if config.WeightProfilesPath != "" {
+		go func() {
+			if err := weightProfileStore.Watch(ctx, config.WeightProfilesPath, config.WeightProfilesReloadInterval); err != nil && err != context.Canceled {
+				sugar.Errorf("Weight profile watcher stopped: %v", err)
+			}
+		}()
+	}
+
+	if config.KarpenterEnabled {
+		dynamicClient, err := dynamic.NewForConfig(kubernetesConfig)
+		if err != nil {
+			sugar.Fatalf("Failed to initialize Kubernetes dynamic client: %v", err)
+		}
+
+		provisioner := provisioners.NewKarpenterProvisioner(dynamicClient, provisioners.KarpenterNodeClassRef{
+			Group: config.KarpenterNodeClassGroup,
+			Kind:  config.KarpenterNodeClassKind,
+			Name:  config.KarpenterNodeClassName,
+		})
+		podObserveSubscriber := subscribers.NewPodObserveSubscriber(eventBus, computeService, provisioner)
+
+		sugar.Info("Starting pod observe subscriber")
+
+		if err := podObserveSubscriber.Start(ctx); err != nil {
+			sugar.Fatalf("Failed to subscribe to pod observe events: %v", err)
+		}
+	}
+
+	go func() {
+		if err := certificateSource.Start(ctx); err != nil && err != context.Canceled {
+			sugar.Errorf("Certificate source stopped: %v", err)
+		}
+	}()
+
+	sugar.Infof("Starting node observer with interval: %s", config.NodeObserverInterval)
+
+	go func() {
+		if err := nodeObserver.Start(ctx, config.NodeObserverInterval); err != nil && err != context.Canceled {
+			sugar.Errorf("Node observer stopped: %v", err)
+		}
+	}()
 
 	sugar.Info("Starting Ultron on %s", config.ServerAddress)
 
 	server := &http.Server{
-		Addr: config.ServerAddress,
-		TLSConfig: &tls.Config{
-			Certificates: []tls.Certificate{cert},
-		},
-		Handler: mux,
+		Addr:              config.ServerAddress,
+		TLSConfig:         services.NewServerTlsConfig(certificateSource, clientCa),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
 	}
 
-	if err := server.ListenAndServeTLS("", ""); err != nil {
-		sugar.Fatalf("Failed to start Ultron: %v", err)
+	go func() {
+		if err := server.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			sugar.Fatalf("Failed to start Ultron: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+
+	sugar.Infof("Shutting down Ultron, draining in-flight requests for up to %s", config.ShutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), config.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		sugar.Errorf("Failed to drain in-flight requests: %v", err)
 	}
+
+	if err := shutdownTracerProvider(shutdownCtx); err != nil {
+		sugar.Errorf("Failed to flush traces: %v", err)
+	}
+
+	if err := redisClient.Close(); err != nil {
+		sugar.Errorf("Failed to close Redis client: %v", err)
+	}
+
+	sugar.Info("Stopped Ultron")
 }
@@ -12,6 +12,24 @@ type IAlgorithm struct {
 	mock.Mock
 }
 
+// Explain provides a mock function with given fields: node, pod
+func (_m *IAlgorithm) Explain(node *pkg.WeightedNode, pod *pkg.WeightedPod) pkg.ScoreExplanation {
+	ret := _m.Called(node, pod)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Explain")
+	}
+
+	var r0 pkg.ScoreExplanation
+	if rf, ok := ret.Get(0).(func(*pkg.WeightedNode, *pkg.WeightedPod) pkg.ScoreExplanation); ok {
+		r0 = rf(node, pod)
+	} else {
+		r0 = ret.Get(0).(pkg.ScoreExplanation)
+	}
+
+	return r0
+}
+
 // NetworkScore provides a mock function with given fields: node, pod
 func (_m *IAlgorithm) NetworkScore(node *pkg.WeightedNode, pod *pkg.WeightedPod) float64 {
 	ret := _m.Called(node, pod)
@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	pkg "github.com/be-heroes/ultron/pkg"
 	mock "github.com/stretchr/testify/mock"
 
@@ -14,17 +16,17 @@ type ICacheService struct {
 	mock.Mock
 }
 
-// AddCacheItem provides a mock function with given fields: key, value, d
-func (_m *ICacheService) AddCacheItem(key string, value interface{}, d time.Duration) error {
-	ret := _m.Called(key, value, d)
+// AddCacheItem provides a mock function with given fields: ctx, key, value, d
+func (_m *ICacheService) AddCacheItem(ctx context.Context, key string, value interface{}, d time.Duration) error {
+	ret := _m.Called(ctx, key, value, d)
 
 	if len(ret) == 0 {
 		panic("no return value specified for AddCacheItem")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, interface{}, time.Duration) error); ok {
-		r0 = rf(key, value, d)
+	if rf, ok := ret.Get(0).(func(context.Context, string, interface{}, time.Duration) error); ok {
+		r0 = rf(ctx, key, value, d)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,9 +34,9 @@ func (_m *ICacheService) AddCacheItem(key string, value interface{}, d time.Dura
 	return r0
 }
 
-// GetAllComputeConfigurations provides a mock function with given fields:
-func (_m *ICacheService) GetAllComputeConfigurations() ([]pkg.ComputeConfiguration, error) {
-	ret := _m.Called()
+// GetAllComputeConfigurations provides a mock function with given fields: ctx
+func (_m *ICacheService) GetAllComputeConfigurations(ctx context.Context) ([]pkg.ComputeConfiguration, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllComputeConfigurations")
@@ -42,19 +44,19 @@ func (_m *ICacheService) GetAllComputeConfigurations() ([]pkg.ComputeConfigurati
 
 	var r0 []pkg.ComputeConfiguration
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]pkg.ComputeConfiguration, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]pkg.ComputeConfiguration, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []pkg.ComputeConfiguration); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []pkg.ComputeConfiguration); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]pkg.ComputeConfiguration)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -62,9 +64,9 @@ func (_m *ICacheService) GetAllComputeConfigurations() ([]pkg.ComputeConfigurati
 	return r0, r1
 }
 
-// GetCacheItem provides a mock function with given fields: key
-func (_m *ICacheService) GetCacheItem(key string) (interface{}, error) {
-	ret := _m.Called(key)
+// GetCacheItem provides a mock function with given fields: ctx, key
+func (_m *ICacheService) GetCacheItem(ctx context.Context, key string) (interface{}, error) {
+	ret := _m.Called(ctx, key)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetCacheItem")
@@ -72,19 +74,19 @@ func (_m *ICacheService) GetCacheItem(key string) (interface{}, error) {
 
 	var r0 interface{}
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string) (interface{}, error)); ok {
-		return rf(key)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (interface{}, error)); ok {
+		return rf(ctx, key)
 	}
-	if rf, ok := ret.Get(0).(func(string) interface{}); ok {
-		r0 = rf(key)
+	if rf, ok := ret.Get(0).(func(context.Context, string) interface{}); ok {
+		r0 = rf(ctx, key)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(interface{})
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(key)
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -92,9 +94,37 @@ func (_m *ICacheService) GetCacheItem(key string) (interface{}, error) {
 	return r0, r1
 }
 
-// GetDurableComputeConfigurations provides a mock function with given fields:
-func (_m *ICacheService) GetDurableComputeConfigurations() ([]pkg.ComputeConfiguration, error) {
-	ret := _m.Called()
+// GetCacheItemUpdatedAt provides a mock function with given fields: key
+func (_m *ICacheService) GetCacheItemUpdatedAt(key string) (time.Time, bool) {
+	ret := _m.Called(key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCacheItemUpdatedAt")
+	}
+
+	var r0 time.Time
+	var r1 bool
+	if rf, ok := ret.Get(0).(func(string) (time.Time, bool)); ok {
+		return rf(key)
+	}
+	if rf, ok := ret.Get(0).(func(string) time.Time); ok {
+		r0 = rf(key)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) bool); ok {
+		r1 = rf(key)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// GetDurableComputeConfigurations provides a mock function with given fields: ctx
+func (_m *ICacheService) GetDurableComputeConfigurations(ctx context.Context) ([]pkg.ComputeConfiguration, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetDurableComputeConfigurations")
@@ -102,19 +132,19 @@ func (_m *ICacheService) GetDurableComputeConfigurations() ([]pkg.ComputeConfigu
 
 	var r0 []pkg.ComputeConfiguration
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]pkg.ComputeConfiguration, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]pkg.ComputeConfiguration, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []pkg.ComputeConfiguration); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []pkg.ComputeConfiguration); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]pkg.ComputeConfiguration)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -122,9 +152,9 @@ func (_m *ICacheService) GetDurableComputeConfigurations() ([]pkg.ComputeConfigu
 	return r0, r1
 }
 
-// GetEphemeralComputeConfigurations provides a mock function with given fields:
-func (_m *ICacheService) GetEphemeralComputeConfigurations() ([]pkg.ComputeConfiguration, error) {
-	ret := _m.Called()
+// GetEphemeralComputeConfigurations provides a mock function with given fields: ctx
+func (_m *ICacheService) GetEphemeralComputeConfigurations(ctx context.Context) ([]pkg.ComputeConfiguration, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetEphemeralComputeConfigurations")
@@ -132,19 +162,19 @@ func (_m *ICacheService) GetEphemeralComputeConfigurations() ([]pkg.ComputeConfi
 
 	var r0 []pkg.ComputeConfiguration
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]pkg.ComputeConfiguration, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]pkg.ComputeConfiguration, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []pkg.ComputeConfiguration); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []pkg.ComputeConfiguration); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]pkg.ComputeConfiguration)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -152,9 +182,9 @@ func (_m *ICacheService) GetEphemeralComputeConfigurations() ([]pkg.ComputeConfi
 	return r0, r1
 }
 
-// GetWeightedInteruptionRates provides a mock function with given fields:
-func (_m *ICacheService) GetWeightedInteruptionRates() ([]pkg.WeightedInteruptionRate, error) {
-	ret := _m.Called()
+// GetWeightedInteruptionRates provides a mock function with given fields: ctx
+func (_m *ICacheService) GetWeightedInteruptionRates(ctx context.Context) ([]pkg.WeightedInteruptionRate, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetWeightedInteruptionRates")
@@ -162,19 +192,19 @@ func (_m *ICacheService) GetWeightedInteruptionRates() ([]pkg.WeightedInteruptio
 
 	var r0 []pkg.WeightedInteruptionRate
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]pkg.WeightedInteruptionRate, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]pkg.WeightedInteruptionRate, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []pkg.WeightedInteruptionRate); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []pkg.WeightedInteruptionRate); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]pkg.WeightedInteruptionRate)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -182,9 +212,9 @@ func (_m *ICacheService) GetWeightedInteruptionRates() ([]pkg.WeightedInteruptio
 	return r0, r1
 }
 
-// GetWeightedLatencyRates provides a mock function with given fields:
-func (_m *ICacheService) GetWeightedLatencyRates() ([]pkg.WeightedLatencyRate, error) {
-	ret := _m.Called()
+// GetWeightedLatencyRates provides a mock function with given fields: ctx
+func (_m *ICacheService) GetWeightedLatencyRates(ctx context.Context) ([]pkg.WeightedLatencyRate, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetWeightedLatencyRates")
@@ -192,19 +222,19 @@ func (_m *ICacheService) GetWeightedLatencyRates() ([]pkg.WeightedLatencyRate, e
 
 	var r0 []pkg.WeightedLatencyRate
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]pkg.WeightedLatencyRate, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]pkg.WeightedLatencyRate, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []pkg.WeightedLatencyRate); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []pkg.WeightedLatencyRate); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]pkg.WeightedLatencyRate)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -212,9 +242,9 @@ func (_m *ICacheService) GetWeightedLatencyRates() ([]pkg.WeightedLatencyRate, e
 	return r0, r1
 }
 
-// GetWeightedNodes provides a mock function with given fields:
-func (_m *ICacheService) GetWeightedNodes() ([]pkg.WeightedNode, error) {
-	ret := _m.Called()
+// GetWeightedNodes provides a mock function with given fields: ctx
+func (_m *ICacheService) GetWeightedNodes(ctx context.Context) ([]pkg.WeightedNode, error) {
+	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetWeightedNodes")
@@ -222,19 +252,19 @@ func (_m *ICacheService) GetWeightedNodes() ([]pkg.WeightedNode, error) {
 
 	var r0 []pkg.WeightedNode
 	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]pkg.WeightedNode, error)); ok {
-		return rf()
+	if rf, ok := ret.Get(0).(func(context.Context) ([]pkg.WeightedNode, error)); ok {
+		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func() []pkg.WeightedNode); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func(context.Context) []pkg.WeightedNode); ok {
+		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]pkg.WeightedNode)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,77 @@
+// Code generated by mockery v2.46.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	services "github.com/be-heroes/ultron/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ICertificateAuthorityStore is an autogenerated mock type for the ICertificateAuthorityStore type
+type ICertificateAuthorityStore struct {
+	mock.Mock
+}
+
+// Load provides a mock function with given fields: ctx
+func (_m *ICertificateAuthorityStore) Load(ctx context.Context) (*services.CertificateAuthority, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Load")
+	}
+
+	var r0 *services.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (*services.CertificateAuthority, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) *services.CertificateAuthority); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, authority
+func (_m *ICertificateAuthorityStore) Save(ctx context.Context, authority *services.CertificateAuthority) error {
+	ret := _m.Called(ctx, authority)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Save")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *services.CertificateAuthority) error); ok {
+		r0 = rf(ctx, authority)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewICertificateAuthorityStore creates a new instance of ICertificateAuthorityStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewICertificateAuthorityStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ICertificateAuthorityStore {
+	mock := &ICertificateAuthorityStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
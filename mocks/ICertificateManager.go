@@ -0,0 +1,162 @@
+// Code generated by mockery v2.46.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	services "github.com/be-heroes/ultron/pkg/services"
+	mock "github.com/stretchr/testify/mock"
+
+	tls "crypto/tls"
+)
+
+// ICertificateManager is an autogenerated mock type for the ICertificateManager type
+type ICertificateManager struct {
+	mock.Mock
+}
+
+// AddRotationHandler provides a mock function with given fields: handler
+func (_m *ICertificateManager) AddRotationHandler(handler func(context.Context, []byte) error) {
+	_m.Called(handler)
+}
+
+// Certificate provides a mock function with given fields:
+func (_m *ICertificateManager) Certificate() (*tls.Certificate, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Certificate")
+	}
+
+	var r0 *tls.Certificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*tls.Certificate, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *tls.Certificate); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tls.Certificate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CertificateAuthority provides a mock function with given fields:
+func (_m *ICertificateManager) CertificateAuthority() (*services.CertificateAuthority, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CertificateAuthority")
+	}
+
+	var r0 *services.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*services.CertificateAuthority, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *services.CertificateAuthority); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCertificate provides a mock function with given fields: hello
+func (_m *ICertificateManager) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	ret := _m.Called(hello)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCertificate")
+	}
+
+	var r0 *tls.Certificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*tls.ClientHelloInfo) (*tls.Certificate, error)); ok {
+		return rf(hello)
+	}
+	if rf, ok := ret.Get(0).(func(*tls.ClientHelloInfo) *tls.Certificate); ok {
+		r0 = rf(hello)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tls.Certificate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*tls.ClientHelloInfo) error); ok {
+		r1 = rf(hello)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Rotate provides a mock function with given fields: ctx
+func (_m *ICertificateManager) Rotate(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Rotate")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Start provides a mock function with given fields: ctx
+func (_m *ICertificateManager) Start(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewICertificateManager creates a new instance of ICertificateManager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewICertificateManager(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ICertificateManager {
+	mock := &ICertificateManager{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -7,6 +7,12 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	pkg "github.com/be-heroes/ultron/pkg"
+
+	services "github.com/be-heroes/ultron/pkg/services"
+
+	time "time"
+
 	tls "crypto/tls"
 )
 
@@ -33,6 +39,36 @@ func (_m *ICertificateService) ExportCACert(caCert []byte, filePath string) erro
 	return r0
 }
 
+// GenerateCertificateAuthority provides a mock function with given fields: organization, commonName, keyAlgorithm, validity
+func (_m *ICertificateService) GenerateCertificateAuthority(organization string, commonName string, keyAlgorithm pkg.KeyAlgorithm, validity time.Duration) (*services.CertificateAuthority, error) {
+	ret := _m.Called(organization, commonName, keyAlgorithm, validity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateCertificateAuthority")
+	}
+
+	var r0 *services.CertificateAuthority
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, pkg.KeyAlgorithm, time.Duration) (*services.CertificateAuthority, error)); ok {
+		return rf(organization, commonName, keyAlgorithm, validity)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, pkg.KeyAlgorithm, time.Duration) *services.CertificateAuthority); ok {
+		r0 = rf(organization, commonName, keyAlgorithm, validity)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*services.CertificateAuthority)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, pkg.KeyAlgorithm, time.Duration) error); ok {
+		r1 = rf(organization, commonName, keyAlgorithm, validity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GenerateSelfSignedCert provides a mock function with given fields: organization, commonName, dnsNames, ipAddresses
 func (_m *ICertificateService) GenerateSelfSignedCert(organization string, commonName string, dnsNames []string, ipAddresses []net.IP) (tls.Certificate, error) {
 	ret := _m.Called(organization, commonName, dnsNames, ipAddresses)
@@ -61,6 +97,34 @@ func (_m *ICertificateService) GenerateSelfSignedCert(organization string, commo
 	return r0, r1
 }
 
+// IssueServingCert provides a mock function with given fields: authority, organization, commonName, dnsNames, ipAddresses, keyAlgorithm, validity
+func (_m *ICertificateService) IssueServingCert(authority *services.CertificateAuthority, organization string, commonName string, dnsNames []string, ipAddresses []net.IP, keyAlgorithm pkg.KeyAlgorithm, validity time.Duration) (tls.Certificate, error) {
+	ret := _m.Called(authority, organization, commonName, dnsNames, ipAddresses, keyAlgorithm, validity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IssueServingCert")
+	}
+
+	var r0 tls.Certificate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*services.CertificateAuthority, string, string, []string, []net.IP, pkg.KeyAlgorithm, time.Duration) (tls.Certificate, error)); ok {
+		return rf(authority, organization, commonName, dnsNames, ipAddresses, keyAlgorithm, validity)
+	}
+	if rf, ok := ret.Get(0).(func(*services.CertificateAuthority, string, string, []string, []net.IP, pkg.KeyAlgorithm, time.Duration) tls.Certificate); ok {
+		r0 = rf(authority, organization, commonName, dnsNames, ipAddresses, keyAlgorithm, validity)
+	} else {
+		r0 = ret.Get(0).(tls.Certificate)
+	}
+
+	if rf, ok := ret.Get(1).(func(*services.CertificateAuthority, string, string, []string, []net.IP, pkg.KeyAlgorithm, time.Duration) error); ok {
+		r1 = rf(authority, organization, commonName, dnsNames, ipAddresses, keyAlgorithm, validity)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewICertificateService creates a new instance of ICertificateService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewICertificateService(t interface {
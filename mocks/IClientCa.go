@@ -0,0 +1,98 @@
+// Code generated by mockery v2.46.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	x509 "crypto/x509"
+)
+
+// IClientCa is an autogenerated mock type for the IClientCa type
+type IClientCa struct {
+	mock.Mock
+}
+
+// CertPool provides a mock function with given fields:
+func (_m *IClientCa) CertPool() (*x509.CertPool, error) {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for CertPool")
+	}
+
+	var r0 *x509.CertPool
+	var r1 error
+	if rf, ok := ret.Get(0).(func() (*x509.CertPool, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() *x509.CertPool); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*x509.CertPool)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Load provides a mock function with given fields: ctx
+func (_m *IClientCa) Load(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Load")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Start provides a mock function with given fields: ctx, interval
+func (_m *IClientCa) Start(ctx context.Context, interval time.Duration) error {
+	ret := _m.Called(ctx, interval)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Start")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) error); ok {
+		r0 = rf(ctx, interval)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIClientCa creates a new instance of IClientCa. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIClientCa(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IClientCa {
+	mock := &IClientCa{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
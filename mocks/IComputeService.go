@@ -3,6 +3,8 @@
 package mocks
 
 import (
+	context "context"
+
 	pkg "github.com/be-heroes/ultron/pkg"
 	mock "github.com/stretchr/testify/mock"
 
@@ -14,9 +16,9 @@ type IComputeService struct {
 	mock.Mock
 }
 
-// CalculateWeightedNodeMedianPrice provides a mock function with given fields: wNode
-func (_m *IComputeService) CalculateWeightedNodeMedianPrice(wNode *pkg.WeightedNode) (float64, error) {
-	ret := _m.Called(wNode)
+// CalculateWeightedNodeMedianPrice provides a mock function with given fields: ctx, wNode
+func (_m *IComputeService) CalculateWeightedNodeMedianPrice(ctx context.Context, wNode *pkg.WeightedNode) (float64, error) {
+	ret := _m.Called(ctx, wNode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CalculateWeightedNodeMedianPrice")
@@ -24,17 +26,17 @@ func (_m *IComputeService) CalculateWeightedNodeMedianPrice(wNode *pkg.WeightedN
 
 	var r0 float64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*pkg.WeightedNode) (float64, error)); ok {
-		return rf(wNode)
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedNode) (float64, error)); ok {
+		return rf(ctx, wNode)
 	}
-	if rf, ok := ret.Get(0).(func(*pkg.WeightedNode) float64); ok {
-		r0 = rf(wNode)
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedNode) float64); ok {
+		r0 = rf(ctx, wNode)
 	} else {
 		r0 = ret.Get(0).(float64)
 	}
 
-	if rf, ok := ret.Get(1).(func(*pkg.WeightedNode) error); ok {
-		r1 = rf(wNode)
+	if rf, ok := ret.Get(1).(func(context.Context, *pkg.WeightedNode) error); ok {
+		r1 = rf(ctx, wNode)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -78,9 +80,39 @@ func (_m *IComputeService) ComputeConfigurationMatchesWeightedPodRequirements(co
 	return r0
 }
 
-// GetInteruptionRateForWeightedNode provides a mock function with given fields: wNode
-func (_m *IComputeService) GetInteruptionRateForWeightedNode(wNode *pkg.WeightedNode) (*pkg.WeightedInteruptionRate, error) {
-	ret := _m.Called(wNode)
+// ExplainPodSpec provides a mock function with given fields: ctx, pod
+func (_m *IComputeService) ExplainPodSpec(ctx context.Context, pod *v1.Pod) ([]pkg.CandidateExplanation, error) {
+	ret := _m.Called(ctx, pod)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExplainPodSpec")
+	}
+
+	var r0 []pkg.CandidateExplanation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Pod) ([]pkg.CandidateExplanation, error)); ok {
+		return rf(ctx, pod)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Pod) []pkg.CandidateExplanation); ok {
+		r0 = rf(ctx, pod)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkg.CandidateExplanation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.Pod) error); ok {
+		r1 = rf(ctx, pod)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInteruptionRateForWeightedNode provides a mock function with given fields: ctx, wNode
+func (_m *IComputeService) GetInteruptionRateForWeightedNode(ctx context.Context, wNode *pkg.WeightedNode) (*pkg.WeightedInteruptionRate, error) {
+	ret := _m.Called(ctx, wNode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetInteruptionRateForWeightedNode")
@@ -88,19 +120,19 @@ func (_m *IComputeService) GetInteruptionRateForWeightedNode(wNode *pkg.Weighted
 
 	var r0 *pkg.WeightedInteruptionRate
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*pkg.WeightedNode) (*pkg.WeightedInteruptionRate, error)); ok {
-		return rf(wNode)
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedNode) (*pkg.WeightedInteruptionRate, error)); ok {
+		return rf(ctx, wNode)
 	}
-	if rf, ok := ret.Get(0).(func(*pkg.WeightedNode) *pkg.WeightedInteruptionRate); ok {
-		r0 = rf(wNode)
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedNode) *pkg.WeightedInteruptionRate); ok {
+		r0 = rf(ctx, wNode)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*pkg.WeightedInteruptionRate)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*pkg.WeightedNode) error); ok {
-		r1 = rf(wNode)
+	if rf, ok := ret.Get(1).(func(context.Context, *pkg.WeightedNode) error); ok {
+		r1 = rf(ctx, wNode)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -108,9 +140,9 @@ func (_m *IComputeService) GetInteruptionRateForWeightedNode(wNode *pkg.Weighted
 	return r0, r1
 }
 
-// GetLatencyRateForWeightedNode provides a mock function with given fields: wNode
-func (_m *IComputeService) GetLatencyRateForWeightedNode(wNode *pkg.WeightedNode) (*pkg.WeightedLatencyRate, error) {
-	ret := _m.Called(wNode)
+// GetLatencyRateForWeightedNode provides a mock function with given fields: ctx, wNode
+func (_m *IComputeService) GetLatencyRateForWeightedNode(ctx context.Context, wNode *pkg.WeightedNode) (*pkg.WeightedLatencyRate, error) {
+	ret := _m.Called(ctx, wNode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetLatencyRateForWeightedNode")
@@ -118,19 +150,19 @@ func (_m *IComputeService) GetLatencyRateForWeightedNode(wNode *pkg.WeightedNode
 
 	var r0 *pkg.WeightedLatencyRate
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*pkg.WeightedNode) (*pkg.WeightedLatencyRate, error)); ok {
-		return rf(wNode)
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedNode) (*pkg.WeightedLatencyRate, error)); ok {
+		return rf(ctx, wNode)
 	}
-	if rf, ok := ret.Get(0).(func(*pkg.WeightedNode) *pkg.WeightedLatencyRate); ok {
-		r0 = rf(wNode)
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedNode) *pkg.WeightedLatencyRate); ok {
+		r0 = rf(ctx, wNode)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*pkg.WeightedLatencyRate)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*pkg.WeightedNode) error); ok {
-		r1 = rf(wNode)
+	if rf, ok := ret.Get(1).(func(context.Context, *pkg.WeightedNode) error); ok {
+		r1 = rf(ctx, wNode)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -138,9 +170,9 @@ func (_m *IComputeService) GetLatencyRateForWeightedNode(wNode *pkg.WeightedNode
 	return r0, r1
 }
 
-// MatchPodSpec provides a mock function with given fields: pod
-func (_m *IComputeService) MatchPodSpec(pod *v1.Pod) (*pkg.WeightedNode, error) {
-	ret := _m.Called(pod)
+// MatchPodSpec provides a mock function with given fields: ctx, pod
+func (_m *IComputeService) MatchPodSpec(ctx context.Context, pod *v1.Pod) (*pkg.WeightedNode, error) {
+	ret := _m.Called(ctx, pod)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MatchPodSpec")
@@ -148,19 +180,19 @@ func (_m *IComputeService) MatchPodSpec(pod *v1.Pod) (*pkg.WeightedNode, error)
 
 	var r0 *pkg.WeightedNode
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*v1.Pod) (*pkg.WeightedNode, error)); ok {
-		return rf(pod)
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Pod) (*pkg.WeightedNode, error)); ok {
+		return rf(ctx, pod)
 	}
-	if rf, ok := ret.Get(0).(func(*v1.Pod) *pkg.WeightedNode); ok {
-		r0 = rf(pod)
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Pod) *pkg.WeightedNode); ok {
+		r0 = rf(ctx, pod)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*pkg.WeightedNode)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*v1.Pod) error); ok {
-		r1 = rf(pod)
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.Pod) error); ok {
+		r1 = rf(ctx, pod)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -168,9 +200,9 @@ func (_m *IComputeService) MatchPodSpec(pod *v1.Pod) (*pkg.WeightedNode, error)
 	return r0, r1
 }
 
-// MatchWeightedNodeToComputeConfiguration provides a mock function with given fields: wNode
-func (_m *IComputeService) MatchWeightedNodeToComputeConfiguration(wNode *pkg.WeightedNode) (*pkg.ComputeConfiguration, error) {
-	ret := _m.Called(wNode)
+// MatchWeightedNodeToComputeConfiguration provides a mock function with given fields: ctx, wNode
+func (_m *IComputeService) MatchWeightedNodeToComputeConfiguration(ctx context.Context, wNode *pkg.WeightedNode) (*pkg.ComputeConfiguration, error) {
+	ret := _m.Called(ctx, wNode)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MatchWeightedNodeToComputeConfiguration")
@@ -178,19 +210,19 @@ func (_m *IComputeService) MatchWeightedNodeToComputeConfiguration(wNode *pkg.We
 
 	var r0 *pkg.ComputeConfiguration
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*pkg.WeightedNode) (*pkg.ComputeConfiguration, error)); ok {
-		return rf(wNode)
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedNode) (*pkg.ComputeConfiguration, error)); ok {
+		return rf(ctx, wNode)
 	}
-	if rf, ok := ret.Get(0).(func(*pkg.WeightedNode) *pkg.ComputeConfiguration); ok {
-		r0 = rf(wNode)
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedNode) *pkg.ComputeConfiguration); ok {
+		r0 = rf(ctx, wNode)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*pkg.ComputeConfiguration)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*pkg.WeightedNode) error); ok {
-		r1 = rf(wNode)
+	if rf, ok := ret.Get(1).(func(context.Context, *pkg.WeightedNode) error); ok {
+		r1 = rf(ctx, wNode)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -198,9 +230,9 @@ func (_m *IComputeService) MatchWeightedNodeToComputeConfiguration(wNode *pkg.We
 	return r0, r1
 }
 
-// MatchWeightedPodToComputeConfiguration provides a mock function with given fields: wPod
-func (_m *IComputeService) MatchWeightedPodToComputeConfiguration(wPod *pkg.WeightedPod) (*pkg.ComputeConfiguration, error) {
-	ret := _m.Called(wPod)
+// MatchWeightedPodToComputeConfiguration provides a mock function with given fields: ctx, wPod
+func (_m *IComputeService) MatchWeightedPodToComputeConfiguration(ctx context.Context, wPod *pkg.WeightedPod) (*pkg.ComputeConfiguration, error) {
+	ret := _m.Called(ctx, wPod)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MatchWeightedPodToComputeConfiguration")
@@ -208,19 +240,19 @@ func (_m *IComputeService) MatchWeightedPodToComputeConfiguration(wPod *pkg.Weig
 
 	var r0 *pkg.ComputeConfiguration
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*pkg.WeightedPod) (*pkg.ComputeConfiguration, error)); ok {
-		return rf(wPod)
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedPod) (*pkg.ComputeConfiguration, error)); ok {
+		return rf(ctx, wPod)
 	}
-	if rf, ok := ret.Get(0).(func(*pkg.WeightedPod) *pkg.ComputeConfiguration); ok {
-		r0 = rf(wPod)
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedPod) *pkg.ComputeConfiguration); ok {
+		r0 = rf(ctx, wPod)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*pkg.ComputeConfiguration)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*pkg.WeightedPod) error); ok {
-		r1 = rf(wPod)
+	if rf, ok := ret.Get(1).(func(context.Context, *pkg.WeightedPod) error); ok {
+		r1 = rf(ctx, wPod)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -228,9 +260,9 @@ func (_m *IComputeService) MatchWeightedPodToComputeConfiguration(wPod *pkg.Weig
 	return r0, r1
 }
 
-// MatchWeightedPodToWeightedNode provides a mock function with given fields: wPod
-func (_m *IComputeService) MatchWeightedPodToWeightedNode(wPod *pkg.WeightedPod) (*pkg.WeightedNode, error) {
-	ret := _m.Called(wPod)
+// MatchWeightedPodToWeightedNode provides a mock function with given fields: ctx, wPod
+func (_m *IComputeService) MatchWeightedPodToWeightedNode(ctx context.Context, wPod *pkg.WeightedPod) (*pkg.WeightedNode, error) {
+	ret := _m.Called(ctx, wPod)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MatchWeightedPodToWeightedNode")
@@ -238,19 +270,109 @@ func (_m *IComputeService) MatchWeightedPodToWeightedNode(wPod *pkg.WeightedPod)
 
 	var r0 *pkg.WeightedNode
 	var r1 error
-	if rf, ok := ret.Get(0).(func(*pkg.WeightedPod) (*pkg.WeightedNode, error)); ok {
-		return rf(wPod)
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedPod) (*pkg.WeightedNode, error)); ok {
+		return rf(ctx, wPod)
 	}
-	if rf, ok := ret.Get(0).(func(*pkg.WeightedPod) *pkg.WeightedNode); ok {
-		r0 = rf(wPod)
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedPod) *pkg.WeightedNode); ok {
+		r0 = rf(ctx, wPod)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*pkg.WeightedNode)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(*pkg.WeightedPod) error); ok {
-		r1 = rf(wPod)
+	if rf, ok := ret.Get(1).(func(context.Context, *pkg.WeightedPod) error); ok {
+		r1 = rf(ctx, wPod)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RankPodSpec provides a mock function with given fields: ctx, pod, limit
+func (_m *IComputeService) RankPodSpec(ctx context.Context, pod *v1.Pod, limit int) ([]pkg.RankedWeightedNode, error) {
+	ret := _m.Called(ctx, pod, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RankPodSpec")
+	}
+
+	var r0 []pkg.RankedWeightedNode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Pod, int) ([]pkg.RankedWeightedNode, error)); ok {
+		return rf(ctx, pod, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Pod, int) []pkg.RankedWeightedNode); ok {
+		r0 = rf(ctx, pod, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkg.RankedWeightedNode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.Pod, int) error); ok {
+		r1 = rf(ctx, pod, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RankWeightedPodToWeightedNodes provides a mock function with given fields: ctx, wPod, limit
+func (_m *IComputeService) RankWeightedPodToWeightedNodes(ctx context.Context, wPod *pkg.WeightedPod, limit int) ([]pkg.RankedWeightedNode, error) {
+	ret := _m.Called(ctx, wPod, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RankWeightedPodToWeightedNodes")
+	}
+
+	var r0 []pkg.RankedWeightedNode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedPod, int) ([]pkg.RankedWeightedNode, error)); ok {
+		return rf(ctx, wPod, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedPod, int) []pkg.RankedWeightedNode); ok {
+		r0 = rf(ctx, wPod, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkg.RankedWeightedNode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *pkg.WeightedPod, int) error); ok {
+		r1 = rf(ctx, wPod, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidatePodSpec provides a mock function with given fields: ctx, pod
+func (_m *IComputeService) ValidatePodSpec(ctx context.Context, pod *v1.Pod) ([]pkg.ConstraintViolation, error) {
+	ret := _m.Called(ctx, pod)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidatePodSpec")
+	}
+
+	var r0 []pkg.ConstraintViolation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Pod) ([]pkg.ConstraintViolation, error)); ok {
+		return rf(ctx, pod)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *v1.Pod) []pkg.ConstraintViolation); ok {
+		r0 = rf(ctx, pod)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pkg.ConstraintViolation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *v1.Pod) error); ok {
+		r1 = rf(ctx, pod)
 	} else {
 		r1 = ret.Error(1)
 	}
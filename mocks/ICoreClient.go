@@ -17,6 +17,36 @@ type ICoreClient struct {
 	mock.Mock
 }
 
+// GetNamespace provides a mock function with given fields: ctx, name, opts
+func (_m *ICoreClient) GetNamespace(ctx context.Context, name string, opts v1.GetOptions) (*corev1.Namespace, error) {
+	ret := _m.Called(ctx, name, opts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNamespace")
+	}
+
+	var r0 *corev1.Namespace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, v1.GetOptions) (*corev1.Namespace, error)); ok {
+		return rf(ctx, name, opts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, v1.GetOptions) *corev1.Namespace); ok {
+		r0 = rf(ctx, name, opts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*corev1.Namespace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, v1.GetOptions) error); ok {
+		r1 = rf(ctx, name, opts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListNamespaces provides a mock function with given fields: ctx, opts
 func (_m *ICoreClient) ListNamespaces(ctx context.Context, opts v1.ListOptions) (*corev1.NamespaceList, error) {
 	ret := _m.Called(ctx, opts)
@@ -0,0 +1,47 @@
+// Code generated by mockery v2.46.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	pkg "github.com/be-heroes/ultron/pkg"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IProvisioner is an autogenerated mock type for the IProvisioner type
+type IProvisioner struct {
+	mock.Mock
+}
+
+// Provision provides a mock function with given fields: ctx, wPod, computeConfiguration
+func (_m *IProvisioner) Provision(ctx context.Context, wPod *pkg.WeightedPod, computeConfiguration *pkg.ComputeConfiguration) error {
+	ret := _m.Called(ctx, wPod, computeConfiguration)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Provision")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *pkg.WeightedPod, *pkg.ComputeConfiguration) error); ok {
+		r0 = rf(ctx, wPod, computeConfiguration)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIProvisioner creates a new instance of IProvisioner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIProvisioner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IProvisioner {
+	mock := &IProvisioner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
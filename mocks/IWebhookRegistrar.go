@@ -0,0 +1,46 @@
+// Code generated by mockery v2.46.2. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// IWebhookRegistrar is an autogenerated mock type for the IWebhookRegistrar type
+type IWebhookRegistrar struct {
+	mock.Mock
+}
+
+// Register provides a mock function with given fields: ctx, caBundle
+func (_m *IWebhookRegistrar) Register(ctx context.Context, caBundle []byte) error {
+	ret := _m.Called(ctx, caBundle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Register")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) error); ok {
+		r0 = rf(ctx, caBundle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewIWebhookRegistrar creates a new instance of IWebhookRegistrar. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewIWebhookRegistrar(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *IWebhookRegistrar {
+	mock := &IWebhookRegistrar{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
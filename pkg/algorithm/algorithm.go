@@ -21,13 +21,21 @@ type IAlgorithm interface {
 	NodeScore(node *ultron.WeightedNode) float64
 	PodScore(pod *ultron.WeightedPod) float64
 	TotalScore(node *ultron.WeightedNode, pod *ultron.WeightedPod) float64
+	Explain(node *ultron.WeightedNode, pod *ultron.WeightedPod) ultron.ScoreExplanation
 }
 
 type Algorithm struct {
+	weightProfileStore IWeightProfileStore
 }
 
-func NewAlgorithm() *Algorithm {
-	return &Algorithm{}
+func NewAlgorithm(weightProfileStore IWeightProfileStore) *Algorithm {
+	if weightProfileStore == nil {
+		weightProfileStore, _ = NewWeightProfileStore(WeightProfileBalanced)
+	}
+
+	return &Algorithm{
+		weightProfileStore: weightProfileStore,
+	}
 }
 
 func (a *Algorithm) ResourceScore(node *ultron.WeightedNode, pod *ultron.WeightedPod) float64 {
@@ -89,12 +97,60 @@ func (a *Algorithm) PodScore(pod *ultron.WeightedPod) float64 {
 }
 
 func (a *Algorithm) TotalScore(node *ultron.WeightedNode, pod *ultron.WeightedPod) float64 {
-	resourceScore := Alpha * a.ResourceScore(node, pod)
-	storageScore := Beta * a.StorageScore(node, pod)
-	networkScore := Gamma * a.NetworkScore(node, pod)
-	priceScore := Delta * a.PriceScore(node)
-	nodeScore := Epsilon * a.NodeScore(node)
-	podScore := Zeta * a.PodScore(pod)
-
-	return resourceScore + storageScore + networkScore + priceScore - nodeScore + podScore
+	return a.Explain(node, pod).TotalScore
+}
+
+func (a *Algorithm) Explain(node *ultron.WeightedNode, pod *ultron.WeightedPod) ultron.ScoreExplanation {
+	profile := a.weightProfileStore.ResolveProfile(pod)
+	components := []ultron.ScoreComponent{
+		newScoreComponent(ultron.ScoreComponentResource, profile.Alpha, a.ResourceScore(node, pod), map[string]interface{}{
+			ultron.WeightKeyCpuAvailable:    node.Weights[ultron.WeightKeyCpuAvailable],
+			ultron.WeightKeyCpuTotal:        node.Weights[ultron.WeightKeyCpuTotal],
+			ultron.WeightKeyCpuRequested:    pod.Weights[ultron.WeightKeyCpuRequested],
+			ultron.WeightKeyMemoryAvailable: node.Weights[ultron.WeightKeyMemoryAvailable],
+			ultron.WeightKeyMemoryTotal:     node.Weights[ultron.WeightKeyMemoryTotal],
+			ultron.WeightKeyMemoryRequested: pod.Weights[ultron.WeightKeyMemoryRequested],
+		}),
+		newScoreComponent(ultron.ScoreComponentStorage, profile.Beta, a.StorageScore(node, pod), map[string]interface{}{
+			"node_disk_type": node.Annotations[ultron.AnnotationDiskType],
+			"pod_disk_type":  pod.Annotations[ultron.AnnotationDiskType],
+		}),
+		newScoreComponent(ultron.ScoreComponentNetwork, profile.Gamma, a.NetworkScore(node, pod), map[string]interface{}{
+			"node_network_type":         node.Annotations[ultron.AnnotationNetworkType],
+			"pod_network_type":          pod.Annotations[ultron.AnnotationNetworkType],
+			ultron.WeightKeyLatencyRate: node.LatencyRate.Weight,
+		}),
+		newScoreComponent(ultron.ScoreComponentPrice, profile.Delta, a.PriceScore(node), map[string]interface{}{
+			ultron.WeightKeyPrice:       node.Weights[ultron.WeightKeyPrice],
+			ultron.WeightKeyPriceMedian: node.Weights[ultron.WeightKeyPriceMedian],
+		}),
+		newScoreComponent(ultron.ScoreComponentNode, -profile.Epsilon, a.NodeScore(node), map[string]interface{}{
+			ultron.WeightKeyPrice:            node.Weights[ultron.WeightKeyPrice],
+			ultron.WeightKeyPriceMedian:      node.Weights[ultron.WeightKeyPriceMedian],
+			ultron.WeightKeyInterruptionRate: node.InterruptionRate.Weight,
+		}),
+		newScoreComponent(ultron.ScoreComponentPod, profile.Zeta, a.PodScore(pod), map[string]interface{}{
+			"workload_priority": pod.Annotations[ultron.AnnotationWorkloadPriority],
+		}),
+	}
+
+	var totalScore float64
+	for _, component := range components {
+		totalScore += component.Contribution
+	}
+
+	return ultron.ScoreExplanation{
+		Components: components,
+		TotalScore: totalScore,
+	}
+}
+
+func newScoreComponent(name string, weight float64, score float64, inputs map[string]interface{}) ultron.ScoreComponent {
+	return ultron.ScoreComponent{
+		Name:         name,
+		Weight:       weight,
+		Score:        score,
+		Contribution: weight * score,
+		Inputs:       inputs,
+	}
 }
@@ -10,7 +10,7 @@ import (
 
 func TestResourceScore(t *testing.T) {
 	// Arrange
-	alg := algorithm.NewAlgorithm()
+	alg := algorithm.NewAlgorithm(nil)
 
 	node := ultron.WeightedNode{
 		Weights: map[string]float64{
@@ -38,7 +38,7 @@ func TestResourceScore(t *testing.T) {
 
 func TestStorageScore(t *testing.T) {
 	// Arrange
-	alg := algorithm.NewAlgorithm()
+	alg := algorithm.NewAlgorithm(nil)
 
 	node := ultron.WeightedNode{
 		Annotations: map[string]string{
@@ -67,7 +67,7 @@ func TestStorageScore(t *testing.T) {
 
 func TestNetworkScore(t *testing.T) {
 	// Arrange
-	alg := algorithm.NewAlgorithm()
+	alg := algorithm.NewAlgorithm(nil)
 
 	node := ultron.WeightedNode{
 		Annotations: map[string]string{
@@ -96,7 +96,7 @@ func TestNetworkScore(t *testing.T) {
 
 func TestPriceScore(t *testing.T) {
 	// Arrange
-	alg := algorithm.NewAlgorithm()
+	alg := algorithm.NewAlgorithm(nil)
 
 	node := ultron.WeightedNode{
 		Weights: map[string]float64{
@@ -120,7 +120,7 @@ func TestPriceScore(t *testing.T) {
 
 func TestNodeScore(t *testing.T) {
 	// Arrange
-	alg := algorithm.NewAlgorithm()
+	alg := algorithm.NewAlgorithm(nil)
 
 	node := ultron.WeightedNode{
 		Weights: map[string]float64{
@@ -145,7 +145,7 @@ func TestNodeScore(t *testing.T) {
 
 func TestPodScore(t *testing.T) {
 	// Arrange
-	alg := algorithm.NewAlgorithm()
+	alg := algorithm.NewAlgorithm(nil)
 
 	pod := ultron.WeightedPod{
 		Annotations: map[string]string{
@@ -168,7 +168,7 @@ func TestPodScore(t *testing.T) {
 
 func TestTotalScore(t *testing.T) {
 	// Arrange
-	alg := algorithm.NewAlgorithm()
+	alg := algorithm.NewAlgorithm(nil)
 
 	node := ultron.WeightedNode{
 		Annotations: map[string]string{
@@ -213,3 +213,48 @@ func TestTotalScore(t *testing.T) {
 	// Assert
 	assert.Equal(t, expected, score, "TotalScore was incorrect")
 }
+
+func TestExplain(t *testing.T) {
+	// Arrange
+	alg := algorithm.NewAlgorithm(nil)
+
+	node := ultron.WeightedNode{
+		Annotations: map[string]string{
+			ultron.AnnotationDiskType: "SSD",
+		},
+		Weights: map[string]float64{
+			ultron.WeightKeyCpuTotal:        8,
+			ultron.WeightKeyCpuAvailable:    4,
+			ultron.WeightKeyMemoryTotal:     16,
+			ultron.WeightKeyMemoryAvailable: 8,
+			ultron.WeightKeyPrice:           10.0,
+			ultron.WeightKeyPriceMedian:     8.0,
+		},
+		InterruptionRate: ultron.WeightedInteruptionRate{Weight: 0.1},
+	}
+
+	pod := ultron.WeightedPod{
+		Annotations: map[string]string{
+			ultron.AnnotationDiskType: "SSD",
+		},
+		Weights: map[string]float64{
+			ultron.WeightKeyCpuRequested:    2,
+			ultron.WeightKeyMemoryRequested: 4,
+		},
+	}
+
+	// Act
+	explanation := alg.Explain(&node, &pod)
+
+	// Assert
+	assert.Len(t, explanation.Components, 6, "Expected one component per score")
+	assert.Equal(t, alg.TotalScore(&node, &pod), explanation.TotalScore, "Expected explanation total to match TotalScore")
+
+	for _, component := range explanation.Components {
+		assert.Equal(t, component.Weight*component.Score, component.Contribution, "Expected contribution to be weight times score for %s", component.Name)
+	}
+
+	assert.Equal(t, ultron.ScoreComponentNode, explanation.Components[4].Name)
+	assert.Equal(t, -algorithm.Epsilon, explanation.Components[4].Weight, "Expected NodeScore to be subtracted")
+	assert.Equal(t, 4.0, explanation.Components[0].Inputs[ultron.WeightKeyCpuAvailable])
+}
@@ -0,0 +1,226 @@
+package algorithm
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	WeightProfileBalanced      = "balanced"
+	WeightProfileCostOptimized = "cost-optimized"
+	WeightProfilePerformance   = "performance"
+)
+
+type WeightProfile struct {
+	Alpha   float64 `json:"alpha"`
+	Beta    float64 `json:"beta"`
+	Gamma   float64 `json:"gamma"`
+	Delta   float64 `json:"delta"`
+	Epsilon float64 `json:"epsilon"`
+	Zeta    float64 `json:"zeta"`
+}
+
+type WeightProfileConfiguration struct {
+	Default  string                   `json:"default,omitempty"`
+	Profiles map[string]WeightProfile `json:"profiles,omitempty"`
+}
+
+type IWeightProfileStore interface {
+	Profile(name string) (WeightProfile, bool)
+	ResolveProfile(pod *ultron.WeightedPod) WeightProfile
+	SetDefaultProfile(name string) error
+	SetNamespaceProfile(namespace string, name string)
+	Load(path string) error
+	Watch(ctx context.Context, path string, interval time.Duration) error
+	HandleClusterEvent(event ultron.ClusterEvent)
+}
+
+type WeightProfileStore struct {
+	mutex             sync.RWMutex
+	defaultProfile    string
+	pinnedProfile     string
+	profiles          map[string]WeightProfile
+	namespaceProfiles map[string]string
+}
+
+func NewWeightProfileStore(defaultProfile string) (*WeightProfileStore, error) {
+	store := &WeightProfileStore{
+		defaultProfile:    WeightProfileBalanced,
+		profiles:          builtinWeightProfiles(),
+		namespaceProfiles: make(map[string]string),
+	}
+
+	if defaultProfile != "" {
+		if err := store.SetDefaultProfile(defaultProfile); err != nil {
+			return nil, err
+		}
+	}
+
+	return store, nil
+}
+
+func (s *WeightProfileStore) Profile(name string) (WeightProfile, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	profile, found := s.profiles[name]
+
+	return profile, found
+}
+
+func (s *WeightProfileStore) ResolveProfile(pod *ultron.WeightedPod) WeightProfile {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if profile, found := s.profiles[pod.Annotations[ultron.AnnotationWeightProfile]]; found {
+		return profile
+	}
+
+	if profile, found := s.profiles[s.namespaceProfiles[pod.Selector[ultron.MetadataNamespace]]]; found {
+		return profile
+	}
+
+	return s.profiles[s.defaultProfile]
+}
+
+func (s *WeightProfileStore) SetDefaultProfile(name string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, found := s.profiles[name]; !found {
+		return fmt.Errorf("unknown weight profile: %s", name)
+	}
+
+	s.defaultProfile = name
+	s.pinnedProfile = name
+
+	return nil
+}
+
+func (s *WeightProfileStore) SetNamespaceProfile(namespace string, name string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if name == "" {
+		delete(s.namespaceProfiles, namespace)
+	} else {
+		s.namespaceProfiles[namespace] = name
+	}
+}
+
+func (s *WeightProfileStore) Load(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read weight profiles from file: %w", err)
+	}
+
+	var configuration WeightProfileConfiguration
+	if err := yaml.Unmarshal(data, &configuration); err != nil {
+		return fmt.Errorf("failed to parse weight profiles from file: %w", err)
+	}
+
+	profiles := builtinWeightProfiles()
+	for name, profile := range configuration.Profiles {
+		profiles[name] = profile
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// A default set explicitly takes precedence over the one in the file, including on reloads.
+	defaultProfile := s.defaultProfile
+	if s.pinnedProfile != "" {
+		defaultProfile = s.pinnedProfile
+	} else if configuration.Default != "" {
+		defaultProfile = configuration.Default
+	}
+
+	if _, found := profiles[defaultProfile]; !found {
+		return fmt.Errorf("unknown default weight profile: %s", defaultProfile)
+	}
+
+	s.profiles = profiles
+	s.defaultProfile = defaultProfile
+
+	return nil
+}
+
+func (s *WeightProfileStore) Watch(ctx context.Context, path string, interval time.Duration) error {
+	var lastModified time.Time
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if fileInfo, err := os.Stat(path); err != nil {
+			log.Printf("Could not stat weight profiles file: %v", err)
+		} else if fileInfo.ModTime() != lastModified {
+			if err := s.Load(path); err != nil {
+				log.Printf("Could not reload weight profiles: %v", err)
+			} else {
+				lastModified = fileInfo.ModTime()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *WeightProfileStore) HandleClusterEvent(event ultron.ClusterEvent) {
+	if event.Kind != ultron.ClusterEventKindNamespace {
+		return
+	}
+
+	namespace, ok := event.Object.(*corev1.Namespace)
+	if !ok {
+		return
+	}
+
+	if event.Type == ultron.ClusterEventTypeDeleted {
+		s.SetNamespaceProfile(namespace.Name, "")
+	} else {
+		s.SetNamespaceProfile(namespace.Name, namespace.Annotations[ultron.AnnotationWeightProfile])
+	}
+}
+
+func builtinWeightProfiles() map[string]WeightProfile {
+	return map[string]WeightProfile{
+		WeightProfileBalanced: {
+			Alpha:   Alpha,
+			Beta:    Beta,
+			Gamma:   Gamma,
+			Delta:   Delta,
+			Epsilon: Epsilon,
+			Zeta:    Zeta,
+		},
+		WeightProfileCostOptimized: {
+			Alpha:   0.5,
+			Beta:    0.25,
+			Gamma:   0.25,
+			Delta:   2.0,
+			Epsilon: 1.5,
+			Zeta:    0.5,
+		},
+		WeightProfilePerformance: {
+			Alpha:   2.0,
+			Beta:    1.0,
+			Gamma:   1.0,
+			Delta:   0.25,
+			Epsilon: 0.5,
+			Zeta:    1.0,
+		},
+	}
+}
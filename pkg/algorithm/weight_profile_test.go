@@ -0,0 +1,165 @@
+package algorithm_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	algorithm "github.com/be-heroes/ultron/pkg/algorithm"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewWeightProfileStore_UnknownDefault(t *testing.T) {
+	// Act
+	_, err := algorithm.NewWeightProfileStore("unknown")
+
+	// Assert
+	assert.Error(t, err, "Expected an error for an unknown default profile")
+}
+
+func TestResolveProfile(t *testing.T) {
+	// Arrange
+	store, _ := algorithm.NewWeightProfileStore(algorithm.WeightProfileBalanced)
+	store.SetNamespaceProfile("batch", algorithm.WeightProfileCostOptimized)
+
+	balanced, _ := store.Profile(algorithm.WeightProfileBalanced)
+	costOptimized, _ := store.Profile(algorithm.WeightProfileCostOptimized)
+	performance, _ := store.Profile(algorithm.WeightProfilePerformance)
+
+	defaultPod := ultron.WeightedPod{Selector: map[string]string{ultron.MetadataNamespace: "default"}}
+	namespacePod := ultron.WeightedPod{Selector: map[string]string{ultron.MetadataNamespace: "batch"}}
+	annotatedPod := ultron.WeightedPod{
+		Selector:    map[string]string{ultron.MetadataNamespace: "batch"},
+		Annotations: map[string]string{ultron.AnnotationWeightProfile: algorithm.WeightProfilePerformance},
+	}
+
+	// Act & Assert
+	assert.Equal(t, balanced, store.ResolveProfile(&defaultPod), "Expected the default profile")
+	assert.Equal(t, costOptimized, store.ResolveProfile(&namespacePod), "Expected the namespace profile")
+	assert.Equal(t, performance, store.ResolveProfile(&annotatedPod), "Expected the pod annotation to take precedence")
+}
+
+func TestWeightProfileStoreLoad(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	_ = os.WriteFile(path, []byte("default: custom\nprofiles:\n  custom:\n    alpha: 3\n    delta: 2\n"), 0644)
+
+	store, _ := algorithm.NewWeightProfileStore("")
+
+	// Act
+	err := store.Load(path)
+	profile := store.ResolveProfile(&ultron.WeightedPod{})
+	_, builtinFound := store.Profile(algorithm.WeightProfilePerformance)
+
+	// Assert
+	assert.NoError(t, err, "Load should not return an error")
+	assert.Equal(t, algorithm.WeightProfile{Alpha: 3, Delta: 2}, profile, "Expected the custom profile to become the default")
+	assert.True(t, builtinFound, "Expected builtin profiles to be retained")
+}
+
+func TestWeightProfileStoreLoad_SetDefaultProfileFromFile(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	_ = os.WriteFile(path, []byte("default: custom\nprofiles:\n  custom:\n    alpha: 3\n  gpu:\n    beta: 4\n"), 0644)
+
+	store, _ := algorithm.NewWeightProfileStore("")
+	_ = store.Load(path)
+
+	// Act
+	err := store.SetDefaultProfile("gpu")
+	reloadErr := store.Load(path)
+	profile := store.ResolveProfile(&ultron.WeightedPod{})
+
+	// Assert
+	assert.NoError(t, err, "Expected a default defined only in the file to be accepted")
+	assert.NoError(t, reloadErr)
+	assert.Equal(t, algorithm.WeightProfile{Beta: 4}, profile, "Expected the explicit default to survive a reload")
+}
+
+func TestWeightProfileStoreWatch_HotReload(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	path := filepath.Join(t.TempDir(), "profiles.yaml")
+	_ = os.WriteFile(path, []byte("profiles:\n  custom:\n    alpha: 1\n"), 0644)
+
+	store, _ := algorithm.NewWeightProfileStore("")
+
+	go func() { _ = store.Watch(ctx, path, 10*time.Millisecond) }()
+
+	assert.Eventually(t, func() bool {
+		_, found := store.Profile("custom")
+
+		return found
+	}, 5*time.Second, 10*time.Millisecond, "Expected the initial profiles to be loaded")
+
+	// Act
+	_ = os.WriteFile(path, []byte("profiles:\n  custom:\n    alpha: 5\n"), 0644)
+	_ = os.Chtimes(path, time.Now().Add(time.Minute), time.Now().Add(time.Minute))
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		profile, _ := store.Profile("custom")
+
+		return profile.Alpha == 5
+	}, 5*time.Second, 10*time.Millisecond, "Expected the changed profiles to be reloaded")
+}
+
+func TestWeightProfileStoreHandleClusterEvent(t *testing.T) {
+	// Arrange
+	store, _ := algorithm.NewWeightProfileStore("")
+	performance, _ := store.Profile(algorithm.WeightProfilePerformance)
+	balanced, _ := store.Profile(algorithm.WeightProfileBalanced)
+	pod := ultron.WeightedPod{Selector: map[string]string{ultron.MetadataNamespace: "web"}}
+
+	namespace := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Annotations: map[string]string{ultron.AnnotationWeightProfile: algorithm.WeightProfilePerformance},
+		},
+	}
+
+	// Act
+	store.HandleClusterEvent(ultron.ClusterEvent{Type: ultron.ClusterEventTypeAdded, Kind: ultron.ClusterEventKindNamespace, Object: namespace})
+	afterAdd := store.ResolveProfile(&pod)
+	store.HandleClusterEvent(ultron.ClusterEvent{Type: ultron.ClusterEventTypeDeleted, Kind: ultron.ClusterEventKindNamespace, Object: namespace})
+	afterDelete := store.ResolveProfile(&pod)
+
+	// Assert
+	assert.Equal(t, performance, afterAdd, "Expected the namespace annotation to select the profile")
+	assert.Equal(t, balanced, afterDelete, "Expected the default profile after the namespace is deleted")
+}
+
+func TestTotalScore_WeightProfile(t *testing.T) {
+	// Arrange
+	store, _ := algorithm.NewWeightProfileStore(algorithm.WeightProfileCostOptimized)
+	alg := algorithm.NewAlgorithm(store)
+	profile, _ := store.Profile(algorithm.WeightProfileCostOptimized)
+
+	node := ultron.WeightedNode{
+		Weights: map[string]float64{
+			ultron.WeightKeyCpuTotal:        8,
+			ultron.WeightKeyCpuAvailable:    4,
+			ultron.WeightKeyMemoryTotal:     16,
+			ultron.WeightKeyMemoryAvailable: 8,
+			ultron.WeightKeyPrice:           10.0,
+			ultron.WeightKeyPriceMedian:     8.0,
+		},
+	}
+
+	pod := ultron.WeightedPod{Weights: map[string]float64{}}
+
+	// Act
+	score := alg.TotalScore(&node, &pod)
+	expected := profile.Alpha*alg.ResourceScore(&node, &pod) + profile.Beta*alg.StorageScore(&node, &pod) + profile.Gamma*alg.NetworkScore(&node, &pod) + profile.Delta*alg.PriceScore(&node) - profile.Epsilon*alg.NodeScore(&node) + profile.Zeta*alg.PodScore(&pod)
+
+	// Assert
+	assert.Equal(t, expected, score, "TotalScore should use the selected weight profile")
+}
@@ -1,14 +1,23 @@
 package pkg
 
 const (
-	AnnotationDiskType         = "ultron.io/disk-type"
-	AnnotationInstanceType     = "ultron.io/instance-type"
-	AnnotationManaged          = "ultron.io/managed"
-	AnnotationNetworkType      = "ultron.io/network-type"
-	AnnotationStorageSizeGb    = "ultron.io/storage-size-gb"
-	AnnotationWorkloadPriority = "ultron.io/workload-priority"
+	AnnotationDiskType           = "ultron.io/disk-type"
+	AnnotationDryRun             = "ultron.io/dry-run"
+	AnnotationFailurePolicy      = "ultron.io/failure-policy"
+	AnnotationInstanceType       = "ultron.io/instance-type"
+	AnnotationManaged            = "ultron.io/managed"
+	AnnotationNetworkType        = "ultron.io/network-type"
+	AnnotationPlaced             = "ultron.io/placed"
+	AnnotationPlacementMode      = "ultron.io/placement-mode"
+	AnnotationSelectorPrecedence = "ultron.io/selector-precedence"
+	AnnotationShadowSelector     = "ultron.io/shadow-selector"
+	AnnotationStorageSizeGb      = "ultron.io/storage-size-gb"
+	AnnotationWeightProfile      = "ultron.io/weight-profile"
+	AnnotationWorkloadPriority   = "ultron.io/workload-priority"
 
 	BlockTypeCertificate   = "CERTIFICATE"
+	BlockTypeEcPrivateKey  = "EC PRIVATE KEY"
+	BlockTypePrivateKey    = "PRIVATE KEY"
 	BlockTypeRsaPrivateKey = "RSA PRIVATE KEY"
 
 	CacheKeyWeightedNodes                                 = "ULTRON_WEIGHTED_NODES"
@@ -17,36 +26,129 @@ const (
 	CacheKeyEphemeralComputeConfigurations                = "ULTRON_EPHEMERAL_COMPUTECONFIGURATION"
 	CacheKeyEphemeralComputeConfigurationInteruptionRates = "ULTRON_EPHEMERAL_COMPUTECONFIGURATION_INTERUPTION_RATES"
 
+	CertificateAuthorityCommonName  = "ultron-ca"
+	CertificateAuthorityStoreFile   = "file"
+	CertificateAuthorityStoreSecret = "secret"
+
+	CertificateSourceFile       = "file"
+	CertificateSourceSecret     = "secret"
+	CertificateSourceSelfSigned = "self-signed"
+
+	ClientCaSourceFile   = "file"
+	ClientCaSourceSecret = "secret"
+
+	ClusterEventKindNamespace = "Namespace"
+	ClusterEventKindNode      = "Node"
+	ClusterEventKindPod       = "Pod"
+
+	ClusterEventTypeAdded   ClusterEventType = "Added"
+	ClusterEventTypeUpdated ClusterEventType = "Updated"
+	ClusterEventTypeDeleted ClusterEventType = "Deleted"
+
+	ComputeConfigurationSourceConfigMap = "configmap"
+	ComputeConfigurationSourceFile      = "file"
+	ComputeConfigurationSourceHttp      = "http"
+
 	ComputeTypeDurable   ComputeType = "durable"
 	ComputeTypeEphemeral ComputeType = "ephemeral"
 
+	DefaultEventBusMaxLen        = 10000
 	DefaultDiskType              = "SSD"
+	DefaultPreferredCandidates   = 3
 	DefaultNetworkType           = "isolated"
 	DefaultStorageSizeGB         = 10.0
 	DefaultDurableInstanceType   = "ultron.durable"
 	DefaultEphemeralInstanceType = "ultron.ephemeral"
 	DefaultWorkloadPriority      = WorkloadPriorityLow
 
-	EnvServerAddress                 = "ULTRON_SERVER_ADDRESS"
-	EnvServerCertificateOrganization = "ULTRON_SERVER_CERTIFICATE_ORGANIZATION"
-	EnvServerCertificateCommonName   = "ULTRON_SERVER_CERTIFICATE_COMMON_NAME"
-	EnvServerCertificateDnsNames     = "ULTRON_SERVER_CERTIFICATE_DNS_NAMES"
-	EnvServerCertificateIpAddresses  = "ULTRON_SERVER_CERTIFICATE_IP_ADDRESSES"
-	EnvServerCertificateExportPath   = "ULTRON_SERVER_CERTIFICATE_EXPORT_PATH"
-	EnvRedisServerAddress            = "ULTRON_SERVER_REDIS_ADDRESS"
-	EnvRedisServerPassword           = "ULTRON_SERVER_REDIS_PASSWORD"
-	EnvRedisServerDatabase           = "ULTRON_SERVER_REDIS_DATABASE"
-	EnvKubernetesConfig              = "KUBECONFIG"
-	EnvKubernetesServiceHost         = "KUBERNETES_SERVICE_HOST"
-	EnvKubernetesServicePort         = "KUBERNETES_SERVICE_PORT"
+	EnvServerAddress                            = "ULTRON_SERVER_ADDRESS"
+	EnvServerCertificateOrganization            = "ULTRON_SERVER_CERTIFICATE_ORGANIZATION"
+	EnvServerCertificateCommonName              = "ULTRON_SERVER_CERTIFICATE_COMMON_NAME"
+	EnvServerCertificateDnsNames                = "ULTRON_SERVER_CERTIFICATE_DNS_NAMES"
+	EnvServerCertificateIpAddresses             = "ULTRON_SERVER_CERTIFICATE_IP_ADDRESSES"
+	EnvServerCertificateExportPath              = "ULTRON_SERVER_CERTIFICATE_EXPORT_PATH"
+	EnvServerCertificateSource                  = "ULTRON_SERVER_CERTIFICATE_SOURCE"
+	EnvServerCertificateLocation                = "ULTRON_SERVER_CERTIFICATE_LOCATION"
+	EnvServerCertificateRenewBefore             = "ULTRON_SERVER_CERTIFICATE_RENEW_BEFORE"
+	EnvServerCertificateValidity                = "ULTRON_SERVER_CERTIFICATE_VALIDITY"
+	EnvServerCertificateKeyAlgorithm            = "ULTRON_SERVER_CERTIFICATE_KEY_ALGORITHM"
+	EnvServerCertificateAuthorityStore          = "ULTRON_SERVER_CERTIFICATE_AUTHORITY_STORE"
+	EnvServerCertificateAuthorityLocation       = "ULTRON_SERVER_CERTIFICATE_AUTHORITY_LOCATION"
+	EnvServerCertificateAuthorityValidity       = "ULTRON_SERVER_CERTIFICATE_AUTHORITY_VALIDITY"
+	EnvServerClientCaSource                     = "ULTRON_SERVER_CLIENT_CA_SOURCE"
+	EnvServerClientCaLocation                   = "ULTRON_SERVER_CLIENT_CA_LOCATION"
+	EnvServerClientCaReloadInterval             = "ULTRON_SERVER_CLIENT_CA_RELOAD_INTERVAL"
+	EnvServerClientAllowedCommonNames           = "ULTRON_SERVER_CLIENT_ALLOWED_COMMON_NAMES"
+	EnvServerLocalCacheTtl                      = "ULTRON_SERVER_LOCAL_CACHE_TTL"
+	EnvServerNodeObserverInterval               = "ULTRON_SERVER_NODE_OBSERVER_INTERVAL"
+	EnvServerInformerResyncPeriod               = "ULTRON_SERVER_INFORMER_RESYNC_PERIOD"
+	EnvServerMetricsPollInterval                = "ULTRON_SERVER_METRICS_POLL_INTERVAL"
+	EnvServerComputeConfigurationSource         = "ULTRON_SERVER_COMPUTE_CONFIGURATION_SOURCE"
+	EnvServerComputeConfigurationLocation       = "ULTRON_SERVER_COMPUTE_CONFIGURATION_LOCATION"
+	EnvServerComputeConfigurationReloadInterval = "ULTRON_SERVER_COMPUTE_CONFIGURATION_RELOAD_INTERVAL"
+	EnvServerWeightProfile                      = "ULTRON_SERVER_WEIGHT_PROFILE"
+	EnvServerWeightProfilesPath                 = "ULTRON_SERVER_WEIGHT_PROFILES_PATH"
+	EnvServerWeightProfilesReloadInterval       = "ULTRON_SERVER_WEIGHT_PROFILES_RELOAD_INTERVAL"
+	EnvServerMutationDryRun                     = "ULTRON_SERVER_MUTATION_DRY_RUN"
+	EnvServerMutationPlacementMode              = "ULTRON_SERVER_MUTATION_PLACEMENT_MODE"
+	EnvServerMutationSelectorPrecedence         = "ULTRON_SERVER_MUTATION_SELECTOR_PRECEDENCE"
+	EnvServerMutationPreferredCandidates        = "ULTRON_SERVER_MUTATION_PREFERRED_CANDIDATES"
+	EnvServerAdmissionTimeout                   = "ULTRON_SERVER_ADMISSION_TIMEOUT"
+	EnvServerAdmissionFailurePolicy             = "ULTRON_SERVER_ADMISSION_FAILURE_POLICY"
+	EnvServerValidationEnforce                  = "ULTRON_SERVER_VALIDATION_ENFORCE"
+	EnvServerEventBusMaxLen                     = "ULTRON_SERVER_EVENT_BUS_MAX_LEN"
+	EnvServerReadinessMaxCacheAge               = "ULTRON_SERVER_READINESS_MAX_CACHE_AGE"
+	EnvServerShutdownTimeout                    = "ULTRON_SERVER_SHUTDOWN_TIMEOUT"
+	EnvServerTracingEndpoint                    = "ULTRON_SERVER_TRACING_ENDPOINT"
+	EnvServerTracingInsecure                    = "ULTRON_SERVER_TRACING_INSECURE"
+	EnvServerTracingSampleRatio                 = "ULTRON_SERVER_TRACING_SAMPLE_RATIO"
+	EnvServerWebhookRegistrationEnabled         = "ULTRON_SERVER_WEBHOOK_REGISTRATION_ENABLED"
+	EnvServerWebhookConfigurationName           = "ULTRON_SERVER_WEBHOOK_CONFIGURATION_NAME"
+	EnvServerWebhookService                     = "ULTRON_SERVER_WEBHOOK_SERVICE"
+	EnvServerWebhookServicePort                 = "ULTRON_SERVER_WEBHOOK_SERVICE_PORT"
+	EnvServerWebhookNamespaceSelector           = "ULTRON_SERVER_WEBHOOK_NAMESPACE_SELECTOR"
+	EnvServerKarpenterEnabled                   = "ULTRON_SERVER_KARPENTER_ENABLED"
+	EnvServerKarpenterNodeClassGroup            = "ULTRON_SERVER_KARPENTER_NODE_CLASS_GROUP"
+	EnvServerKarpenterNodeClassKind             = "ULTRON_SERVER_KARPENTER_NODE_CLASS_KIND"
+	EnvServerKarpenterNodeClassName             = "ULTRON_SERVER_KARPENTER_NODE_CLASS_NAME"
+	EnvRedisServerAddress                       = "ULTRON_SERVER_REDIS_ADDRESS"
+	EnvRedisServerPassword                      = "ULTRON_SERVER_REDIS_PASSWORD"
+	EnvRedisServerDatabase                      = "ULTRON_SERVER_REDIS_DATABASE"
+	EnvKubernetesConfig                         = "KUBECONFIG"
+	EnvKubernetesServiceHost                    = "KUBERNETES_SERVICE_HOST"
+	EnvKubernetesServicePort                    = "KUBERNETES_SERVICE_PORT"
 
+	FailurePolicyClosed FailurePolicy = "closed"
+	FailurePolicyOpen   FailurePolicy = "open"
+
+	KeyAlgorithmEcdsa KeyAlgorithm = "ecdsa"
+	KeyAlgorithmRsa   KeyAlgorithm = "rsa"
+
+	LabelComputeType  = "ultron.io/compute-type"
 	LabelHostName     = "kubernetes.io/hostname"
 	LabelInstanceType = "node.kubernetes.io/instance-type"
 
-	MetadataName = "metadata.name"
+	MetadataName      = "metadata.name"
+	MetadataNamespace = "metadata.namespace"
+
+	PlacementModeNodeSelector      PlacementMode = "nodeSelector"
+	PlacementModePreferredAffinity PlacementMode = "preferredAffinity"
+	PlacementModeRequiredAffinity  PlacementMode = "requiredAffinity"
+
+	ScoreComponentNetwork  = "NetworkScore"
+	ScoreComponentNode     = "NodeScore"
+	ScoreComponentPod      = "PodScore"
+	ScoreComponentPrice    = "PriceScore"
+	ScoreComponentResource = "ResourceScore"
+	ScoreComponentStorage  = "StorageScore"
+
+	SelectorPrecedenceSkip     SelectorPrecedence = "skip"
+	SelectorPrecedenceUltron   SelectorPrecedence = "ultron"
+	SelectorPrecedenceWorkload SelectorPrecedence = "workload"
 
-	TopicNodeObserve = "ULTRON_TOPIC_NODE_OBSERVE"
-	TopicPodObserve  = "ULTRON_TOPIC_POD_OBSERVE"
+	TopicCacheInvalidate = "ULTRON_TOPIC_CACHE_INVALIDATE"
+	TopicNodeObserve     = "ULTRON_TOPIC_NODE_OBSERVE"
+	TopicPodObserve      = "ULTRON_TOPIC_POD_OBSERVE"
 
 	WeightKeyCpuAvailable     = "cpu_available"
 	WeightKeyCpuLimit         = "cpu_limit"
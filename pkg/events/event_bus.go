@@ -0,0 +1,25 @@
+package events
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	deadLetterTopicSuffix = "_DEAD_LETTER"
+	eventMaxDeliveries    = 5
+	eventRetryInterval    = 30 * time.Second
+)
+
+type ObserveEventHandler func(ctx context.Context, event *ObserveEvent) error
+
+type IEventBus interface {
+	Publish(ctx context.Context, topic string, event *ObserveEvent) error
+	Subscribe(ctx context.Context, topic string, group string, handler ObserveEventHandler) error
+	Replay(ctx context.Context, topic string, since time.Time, handler ObserveEventHandler) error
+}
+
+// DeadLetterTopic names the topic that receives events whose handler kept failing on topic.
+func DeadLetterTopic(topic string) string {
+	return topic + deadLetterTopicSuffix
+}
@@ -0,0 +1,165 @@
+package events
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+)
+
+type inMemoryStream struct {
+	events   []*ObserveEvent
+	trimmed  int
+	offsets  map[string]int
+	attempts map[string]int
+	notify   map[string]chan struct{}
+}
+
+type InMemoryEventBus struct {
+	mutex   sync.Mutex
+	maxLen  int
+	streams map[string]*inMemoryStream
+}
+
+func NewInMemoryEventBus(maxLen int) *InMemoryEventBus {
+	return &InMemoryEventBus{
+		maxLen:  maxLen,
+		streams: make(map[string]*inMemoryStream),
+	}
+}
+
+func (b *InMemoryEventBus) Publish(ctx context.Context, topic string, event *ObserveEvent) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	stream := b.stream(topic)
+	stream.events = append(stream.events, event)
+
+	if b.maxLen > 0 && len(stream.events) > b.maxLen {
+		overflow := len(stream.events) - b.maxLen
+		stream.events = stream.events[overflow:]
+		stream.trimmed += overflow
+	}
+
+	for _, notify := range stream.notify {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+func (b *InMemoryEventBus) Subscribe(ctx context.Context, topic string, group string, handler ObserveEventHandler) error {
+	b.mutex.Lock()
+
+	stream := b.stream(topic)
+
+	notify, exists := stream.notify[group]
+	if !exists {
+		notify = make(chan struct{}, 1)
+		stream.notify[group] = notify
+	}
+
+	b.mutex.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(eventRetryInterval)
+		defer ticker.Stop()
+
+		for {
+			b.deliver(ctx, topic, group, handler)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-notify:
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (b *InMemoryEventBus) Replay(ctx context.Context, topic string, since time.Time, handler ObserveEventHandler) error {
+	b.mutex.Lock()
+	events := append([]*ObserveEvent{}, b.stream(topic).events...)
+	b.mutex.Unlock()
+
+	for _, event := range events {
+		if event.Time.Before(since) {
+			continue
+		}
+
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (b *InMemoryEventBus) deliver(ctx context.Context, topic string, group string, handler ObserveEventHandler) {
+	for ctx.Err() == nil {
+		b.mutex.Lock()
+
+		stream := b.stream(topic)
+		offset := stream.offsets[group]
+
+		if offset < stream.trimmed {
+			offset = stream.trimmed
+			stream.attempts[group] = 0
+		}
+
+		if offset-stream.trimmed >= len(stream.events) {
+			b.mutex.Unlock()
+
+			return
+		}
+
+		event := stream.events[offset-stream.trimmed]
+
+		b.mutex.Unlock()
+
+		if err := handler(ctx, event); err != nil {
+			log.Printf("Could not handle %s event %s: %v", event.Type, event.Id, err)
+
+			b.mutex.Lock()
+			stream.attempts[group]++
+			exhausted := stream.attempts[group] >= eventMaxDeliveries
+			b.mutex.Unlock()
+
+			if !exhausted {
+				return
+			}
+
+			log.Printf("Dead-lettered %s event %s on %s after %d deliveries", event.Type, event.Id, topic, eventMaxDeliveries)
+			metrics.EventDeadLettersTotal.WithLabelValues(topic).Inc()
+
+			_ = b.Publish(ctx, DeadLetterTopic(topic), event)
+		}
+
+		b.mutex.Lock()
+		stream.offsets[group] = offset + 1
+		stream.attempts[group] = 0
+		b.mutex.Unlock()
+	}
+}
+
+func (b *InMemoryEventBus) stream(topic string) *inMemoryStream {
+	stream, exists := b.streams[topic]
+	if !exists {
+		stream = &inMemoryStream{
+			offsets:  make(map[string]int),
+			attempts: make(map[string]int),
+			notify:   make(map[string]chan struct{}),
+		}
+		b.streams[topic] = stream
+	}
+
+	return stream
+}
@@ -0,0 +1,103 @@
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	events "github.com/be-heroes/ultron/pkg/events"
+)
+
+func TestInMemoryEventBus_ConsumerGroups(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventBus := events.NewInMemoryEventBus(0)
+	firstRecorder := &eventRecorder{}
+	secondRecorder := &eventRecorder{}
+
+	_ = eventBus.Publish(ctx, ultron.TopicPodObserve, events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Name: "pod1"}))
+
+	// Act
+	_ = eventBus.Subscribe(ctx, ultron.TopicPodObserve, "group-1", firstRecorder.Handle)
+	_ = eventBus.Subscribe(ctx, ultron.TopicPodObserve, "group-2", secondRecorder.Handle)
+	_ = eventBus.Publish(ctx, ultron.TopicPodObserve, events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Name: "pod2"}))
+
+	// Assert
+	assert.Eventually(t, func() bool { return firstRecorder.Len() == 2 }, 5*time.Second, 10*time.Millisecond, "Expected every group to receive all events")
+	assert.Eventually(t, func() bool { return secondRecorder.Len() == 2 }, 5*time.Second, 10*time.Millisecond, "Expected every group to receive all events")
+}
+
+func TestInMemoryEventBus_RetriesFailedEvents(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventBus := events.NewInMemoryEventBus(0)
+	recorder := &eventRecorder{fail: true}
+
+	_ = eventBus.Subscribe(ctx, ultron.TopicPodObserve, "group-1", recorder.Handle)
+	_ = eventBus.Publish(ctx, ultron.TopicPodObserve, events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Name: "pod1"}))
+
+	time.Sleep(50 * time.Millisecond)
+
+	recorder.mutex.Lock()
+	recorder.fail = false
+	recorder.mutex.Unlock()
+
+	// Act
+	_ = eventBus.Publish(ctx, ultron.TopicPodObserve, events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Name: "pod2"}))
+
+	// Assert
+	assert.Eventually(t, func() bool { return recorder.Len() == 2 }, 5*time.Second, 10*time.Millisecond, "Expected the failed event to be retried")
+	assert.Equal(t, "pod1", recorder.events[0].Data.Name)
+}
+
+func TestInMemoryEventBus_MaxLenAndReplay(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	eventBus := events.NewInMemoryEventBus(2)
+	recorder := &eventRecorder{}
+
+	for i := 0; i < 5; i++ {
+		_ = eventBus.Publish(ctx, ultron.TopicNodeObserve, events.NewObserveEvent(events.EventTypeNodeObserved, events.ObserveEventData{Name: fmt.Sprintf("pod%d", i)}))
+	}
+
+	// Act
+	err := eventBus.Replay(ctx, ultron.TopicNodeObserve, time.Time{}, recorder.Handle)
+
+	// Assert
+	assert.NoError(t, err, "Replay should not return an error")
+	assert.Equal(t, 2, recorder.Len(), "Expected the stream to be trimmed to its max length")
+	assert.Equal(t, "pod3", recorder.events[0].Data.Name)
+	assert.Equal(t, "pod4", recorder.events[1].Data.Name)
+}
+
+func TestInMemoryEventBus_DeadLettersFailingEvents(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	eventBus := events.NewInMemoryEventBus(0)
+	failingRecorder := &eventRecorder{fail: true}
+	deadLetters := &eventRecorder{}
+
+	_ = eventBus.Subscribe(ctx, ultron.TopicPodObserve, "group-1", failingRecorder.Handle)
+	_ = eventBus.Subscribe(ctx, events.DeadLetterTopic(ultron.TopicPodObserve), "group-1", deadLetters.Handle)
+
+	// Act
+	_ = eventBus.Publish(ctx, ultron.TopicPodObserve, events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Name: "pod1"}))
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		_ = eventBus.Publish(ctx, ultron.TopicPodObserve, events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Name: "retry"}))
+
+		return deadLetters.Len() > 0
+	}, 5*time.Second, 10*time.Millisecond, "Expected an event that keeps failing to be dead-lettered")
+	assert.Equal(t, "pod1", deadLetters.events[0].Data.Name)
+}
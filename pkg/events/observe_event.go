@@ -0,0 +1,86 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+
+	"github.com/google/uuid"
+)
+
+const (
+	ContentTypeJson        = "application/json"
+	CloudEventsSpecVersion = "1.0"
+	EventSchemaVersion     = "v1"
+	EventSource            = "ultron"
+	EventTypeNodeObserved  = "io.ultron.node.observed"
+	EventTypePodObserved   = "io.ultron.pod.observed"
+)
+
+type ObserveEventData struct {
+	AdmissionUid string                   `json:"admissionUid,omitempty"`
+	Kind         string                   `json:"kind,omitempty"`
+	Namespace    string                   `json:"namespace,omitempty"`
+	Name         string                   `json:"name,omitempty"`
+	Pod          *ultron.WeightedPod      `json:"pod,omitempty"`
+	Node         *ultron.WeightedNode     `json:"node,omitempty"`
+	Scores       *ultron.ScoreExplanation `json:"scores,omitempty"`
+}
+
+type ObserveEvent struct {
+	SpecVersion     string           `json:"specversion"`
+	Id              string           `json:"id"`
+	Source          string           `json:"source"`
+	Type            string           `json:"type"`
+	Subject         string           `json:"subject,omitempty"`
+	Time            time.Time        `json:"time"`
+	DataContentType string           `json:"datacontenttype"`
+	SchemaVersion   string           `json:"ultronschemaversion"`
+	Data            ObserveEventData `json:"data"`
+}
+
+func NewObserveEvent(eventType string, data ObserveEventData) *ObserveEvent {
+	subject := data.Name
+	if data.Namespace != "" {
+		subject = data.Namespace + "/" + data.Name
+	}
+
+	return &ObserveEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		Id:              uuid.NewString(),
+		Source:          EventSource,
+		Type:            eventType,
+		Subject:         subject,
+		Time:            time.Now().UTC(),
+		DataContentType: ContentTypeJson,
+		SchemaVersion:   EventSchemaVersion,
+		Data:            data,
+	}
+}
+
+func EncodeObserveEvent(event *ObserveEvent) ([]byte, error) {
+	return json.Marshal(event)
+}
+
+func DecodeObserveEvent(payload []byte) (*ObserveEvent, error) {
+	var event ObserveEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("failed to decode observe event: %w", err)
+	}
+
+	if event.SpecVersion != CloudEventsSpecVersion {
+		return nil, fmt.Errorf("unsupported cloud events spec version: %q", event.SpecVersion)
+	}
+
+	if event.SchemaVersion != EventSchemaVersion {
+		return nil, fmt.Errorf("unsupported observe event schema version: %q", event.SchemaVersion)
+	}
+
+	if event.Type != EventTypeNodeObserved && event.Type != EventTypePodObserved {
+		return nil, fmt.Errorf("unsupported observe event type: %q", event.Type)
+	}
+
+	return &event, nil
+}
@@ -0,0 +1,59 @@
+package events_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	events "github.com/be-heroes/ultron/pkg/events"
+)
+
+func TestObserveEvent_RoundTrip(t *testing.T) {
+	// Arrange
+	event := events.NewObserveEvent(events.EventTypeNodeObserved, events.ObserveEventData{
+		AdmissionUid: "1234",
+		Namespace:    "default",
+		Name:         "pod1",
+		Pod:          &ultron.WeightedPod{Selector: map[string]string{ultron.MetadataName: "pod1"}},
+		Node:         &ultron.WeightedNode{Selector: map[string]string{ultron.LabelHostName: "node1"}},
+		Scores:       &ultron.ScoreExplanation{TotalScore: 1.5},
+	})
+
+	// Act
+	payload, encodeErr := events.EncodeObserveEvent(event)
+	decoded, decodeErr := events.DecodeObserveEvent(payload)
+
+	// Assert
+	assert.NoError(t, encodeErr, "EncodeObserveEvent should not return an error")
+	assert.NoError(t, decodeErr, "DecodeObserveEvent should not return an error")
+	assert.Equal(t, "default/pod1", decoded.Subject)
+	assert.Equal(t, events.CloudEventsSpecVersion, decoded.SpecVersion)
+	assert.Equal(t, events.EventSchemaVersion, decoded.SchemaVersion)
+	assert.Equal(t, event.Id, decoded.Id)
+	assert.True(t, event.Time.Equal(decoded.Time))
+	assert.Equal(t, "node1", decoded.Data.Node.Selector[ultron.LabelHostName])
+	assert.Equal(t, 1.5, decoded.Data.Scores.TotalScore)
+}
+
+func TestDecodeObserveEvent_UnsupportedSchemaVersion(t *testing.T) {
+	// Arrange
+	payload := []byte(`{"specversion":"1.0","type":"io.ultron.pod.observed","ultronschemaversion":"v0","data":{}}`)
+
+	// Act
+	_, err := events.DecodeObserveEvent(payload)
+
+	// Assert
+	assert.Error(t, err, "Expected an error for an unsupported schema version")
+}
+
+func TestDecodeObserveEvent_RawPayload(t *testing.T) {
+	// Arrange
+	payload := []byte(`{"Selector":{"metadata.name":"pod1"}}`)
+
+	// Act
+	_, err := events.DecodeObserveEvent(payload)
+
+	// Assert
+	assert.Error(t, err, "Expected an error for payloads without an envelope")
+}
@@ -0,0 +1,274 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	redisStreamEventField   = "event"
+	redisStreamBatchSize    = 16
+	redisStreamBlock        = 5 * time.Second
+	redisStreamClaimMinIdle = time.Minute
+)
+
+type RedisStreamEventBus struct {
+	redisClient  *redis.Client
+	maxLen       int64
+	consumerName string
+}
+
+func NewRedisStreamEventBus(redisClient *redis.Client, maxLen int64, consumerName string) *RedisStreamEventBus {
+	return &RedisStreamEventBus{
+		redisClient:  redisClient,
+		maxLen:       maxLen,
+		consumerName: consumerName,
+	}
+}
+
+func (b *RedisStreamEventBus) Publish(ctx context.Context, topic string, event *ObserveEvent) error {
+	payload, err := EncodeObserveEvent(event)
+	if err != nil {
+		return err
+	}
+
+	err = b.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: topic,
+		MaxLen: b.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{redisStreamEventField: payload},
+	}).Err()
+	if err != nil {
+		metrics.RedisPublishFailuresTotal.WithLabelValues(topic).Inc()
+
+		return err
+	}
+
+	return nil
+}
+
+func (b *RedisStreamEventBus) Subscribe(ctx context.Context, topic string, group string, handler ObserveEventHandler) error {
+	err := b.redisClient.XGroupCreateMkStream(ctx, topic, group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group %s: %w", group, err)
+	}
+
+	go func() {
+		// Redeliver messages this consumer read but never acknowledged before reading new ones.
+		if err := b.consumePending(ctx, topic, group, handler); err != nil && ctx.Err() == nil {
+			log.Printf("Could not consume pending messages on %s: %v", topic, err)
+		}
+
+		lastReclaim := time.Now()
+
+		for ctx.Err() == nil {
+			if err := b.consumeNew(ctx, topic, group, handler); err != nil && ctx.Err() == nil {
+				log.Printf("Could not consume messages on %s: %v", topic, err)
+
+				time.Sleep(time.Second)
+			}
+
+			if time.Since(lastReclaim) < eventRetryInterval {
+				continue
+			}
+
+			if err := b.ReclaimPending(ctx, topic, group, handler); err != nil && ctx.Err() == nil {
+				log.Printf("Could not reclaim pending messages on %s: %v", topic, err)
+			}
+
+			lastReclaim = time.Now()
+		}
+	}()
+
+	return nil
+}
+
+func (b *RedisStreamEventBus) Replay(ctx context.Context, topic string, since time.Time, handler ObserveEventHandler) error {
+	start := "-"
+	if since.UnixMilli() > 0 {
+		start = fmt.Sprintf("%d-0", since.UnixMilli())
+	}
+
+	messages, err := b.redisClient.XRange(ctx, topic, start, "+").Result()
+	if err != nil {
+		return err
+	}
+
+	for _, message := range messages {
+		event, err := decodeStreamMessage(message)
+		if err != nil {
+			log.Printf("Skipping message %s on %s: %v", message.ID, topic, err)
+
+			continue
+		}
+
+		if err := handler(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReclaimPending retries messages that stayed unacknowledged for longer than the claim idle time,
+// including those left behind by consumers that no longer exist, and dead-letters messages that
+// exhausted their deliveries.
+func (b *RedisStreamEventBus) ReclaimPending(ctx context.Context, topic string, group string, handler ObserveEventHandler) error {
+	for {
+		pending, err := b.redisClient.XPendingExt(ctx, &redis.XPendingExtArgs{
+			Stream: topic,
+			Group:  group,
+			Idle:   redisStreamClaimMinIdle,
+			Start:  "-",
+			End:    "+",
+			Count:  redisStreamBatchSize,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read pending messages: %w", err)
+		}
+
+		for _, entry := range pending {
+			messages, err := b.redisClient.XClaim(ctx, &redis.XClaimArgs{
+				Stream:   topic,
+				Group:    group,
+				Consumer: b.consumerName,
+				MinIdle:  redisStreamClaimMinIdle,
+				Messages: []string{entry.ID},
+			}).Result()
+			if err != nil && !errors.Is(err, redis.Nil) {
+				return fmt.Errorf("failed to claim message %s: %w", entry.ID, err)
+			}
+
+			for _, message := range messages {
+				// Messages trimmed from the stream come back without values and are dropped by handleMessage.
+				if entry.RetryCount >= eventMaxDeliveries && len(message.Values) > 0 {
+					b.deadLetter(ctx, topic, group, message)
+				} else {
+					b.handleMessage(ctx, topic, group, message, handler)
+				}
+			}
+		}
+
+		if len(pending) < redisStreamBatchSize {
+			return nil
+		}
+	}
+}
+
+func (b *RedisStreamEventBus) consumePending(ctx context.Context, topic string, group string, handler ObserveEventHandler) error {
+	start := "0"
+
+	for {
+		streams, err := b.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    group,
+			Consumer: b.consumerName,
+			Streams:  []string{topic, start},
+			Count:    redisStreamBatchSize,
+			Block:    -1,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		delivered := 0
+
+		for _, stream := range streams {
+			for _, message := range stream.Messages {
+				b.handleMessage(ctx, topic, group, message, handler)
+
+				start = message.ID
+				delivered++
+			}
+		}
+
+		if delivered == 0 {
+			return nil
+		}
+	}
+}
+
+func (b *RedisStreamEventBus) consumeNew(ctx context.Context, topic string, group string, handler ObserveEventHandler) error {
+	streams, err := b.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: b.consumerName,
+		Streams:  []string{topic, ">"},
+		Count:    redisStreamBatchSize,
+		Block:    redisStreamBlock,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, stream := range streams {
+		for _, message := range stream.Messages {
+			b.handleMessage(ctx, topic, group, message, handler)
+		}
+	}
+
+	return nil
+}
+
+func (b *RedisStreamEventBus) handleMessage(ctx context.Context, topic string, group string, message redis.XMessage, handler ObserveEventHandler) {
+	event, err := decodeStreamMessage(message)
+	if err != nil {
+		log.Printf("Dropping message %s on %s: %v", message.ID, topic, err)
+	} else if err := handler(ctx, event); err != nil {
+		log.Printf("Could not handle %s event %s: %v", event.Type, event.Id, err)
+
+		return
+	}
+
+	if err := b.redisClient.XAck(ctx, topic, group, message.ID).Err(); err != nil {
+		log.Printf("Could not acknowledge message %s on %s: %v", message.ID, topic, err)
+	}
+}
+
+func (b *RedisStreamEventBus) deadLetter(ctx context.Context, topic string, group string, message redis.XMessage) {
+	err := b.redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: DeadLetterTopic(topic),
+		MaxLen: b.maxLen,
+		Approx: true,
+		Values: message.Values,
+	}).Err()
+	if err != nil {
+		log.Printf("Could not dead-letter message %s on %s: %v", message.ID, topic, err)
+
+		return
+	}
+
+	log.Printf("Dead-lettered message %s on %s after %d deliveries", message.ID, topic, eventMaxDeliveries)
+	metrics.EventDeadLettersTotal.WithLabelValues(topic).Inc()
+
+	if err := b.redisClient.XAck(ctx, topic, group, message.ID).Err(); err != nil {
+		log.Printf("Could not acknowledge message %s on %s: %v", message.ID, topic, err)
+	}
+}
+
+func decodeStreamMessage(message redis.XMessage) (*ObserveEvent, error) {
+	payload, ok := message.Values[redisStreamEventField].(string)
+	if !ok {
+		return nil, fmt.Errorf("missing %s field", redisStreamEventField)
+	}
+
+	return DecodeObserveEvent([]byte(payload))
+}
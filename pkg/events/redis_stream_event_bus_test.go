@@ -0,0 +1,189 @@
+package events_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	events "github.com/be-heroes/ultron/pkg/events"
+)
+
+func newTestRedisClient(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	server := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	t.Cleanup(func() { redisClient.Close() })
+
+	return server, redisClient
+}
+
+type eventRecorder struct {
+	mutex  sync.Mutex
+	events []*events.ObserveEvent
+	fail   bool
+}
+
+func (r *eventRecorder) Handle(ctx context.Context, event *events.ObserveEvent) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.fail {
+		return fmt.Errorf("handler failed")
+	}
+
+	r.events = append(r.events, event)
+
+	return nil
+}
+
+func (r *eventRecorder) Len() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return len(r.events)
+}
+
+func TestRedisStreamEventBus_PublishAndSubscribe(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, redisClient := newTestRedisClient(t)
+	eventBus := events.NewRedisStreamEventBus(redisClient, 100, "consumer-1")
+	recorder := &eventRecorder{}
+
+	// Act
+	publishErr := eventBus.Publish(ctx, ultron.TopicPodObserve, events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Name: "pod1"}))
+	subscribeErr := eventBus.Subscribe(ctx, ultron.TopicPodObserve, "group-1", recorder.Handle)
+
+	// Assert
+	assert.NoError(t, publishErr, "Publish should not return an error")
+	assert.NoError(t, subscribeErr, "Subscribe should not return an error")
+	assert.Eventually(t, func() bool { return recorder.Len() == 1 }, 5*time.Second, 10*time.Millisecond, "Expected events published before subscribing to be delivered")
+	assert.Eventually(t, func() bool {
+		pending, err := redisClient.XPending(ctx, ultron.TopicPodObserve, "group-1").Result()
+
+		return err == nil && pending.Count == 0
+	}, 5*time.Second, 10*time.Millisecond, "Expected delivered events to be acknowledged")
+}
+
+func TestRedisStreamEventBus_RedeliversUnacknowledged(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	_, redisClient := newTestRedisClient(t)
+	eventBus := events.NewRedisStreamEventBus(redisClient, 100, "consumer-1")
+	failingRecorder := &eventRecorder{fail: true}
+	recorder := &eventRecorder{}
+
+	_ = eventBus.Publish(ctx, ultron.TopicPodObserve, events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Name: "pod1"}))
+
+	failingCtx, cancelFailing := context.WithCancel(ctx)
+	_ = eventBus.Subscribe(failingCtx, ultron.TopicPodObserve, "group-1", failingRecorder.Handle)
+
+	assert.Eventually(t, func() bool {
+		pending, err := redisClient.XPending(ctx, ultron.TopicPodObserve, "group-1").Result()
+
+		return err == nil && pending.Count == 1
+	}, 5*time.Second, 10*time.Millisecond, "Expected failed events to stay pending")
+
+	cancelFailing()
+
+	// Act
+	restartCtx, cancelRestart := context.WithCancel(ctx)
+	defer cancelRestart()
+
+	err := eventBus.Subscribe(restartCtx, ultron.TopicPodObserve, "group-1", recorder.Handle)
+
+	// Assert
+	assert.NoError(t, err, "Subscribe should not return an error for an existing group")
+	assert.Eventually(t, func() bool { return recorder.Len() == 1 }, 5*time.Second, 10*time.Millisecond, "Expected pending events to be redelivered")
+}
+
+func TestRedisStreamEventBus_MaxLenAndReplay(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	_, redisClient := newTestRedisClient(t)
+	eventBus := events.NewRedisStreamEventBus(redisClient, 2, "consumer-1")
+	recorder := &eventRecorder{}
+
+	for i := 0; i < 5; i++ {
+		_ = eventBus.Publish(ctx, ultron.TopicNodeObserve, events.NewObserveEvent(events.EventTypeNodeObserved, events.ObserveEventData{Name: fmt.Sprintf("pod%d", i)}))
+	}
+
+	// Act
+	err := eventBus.Replay(ctx, ultron.TopicNodeObserve, time.Time{}, recorder.Handle)
+
+	// Assert
+	assert.NoError(t, err, "Replay should not return an error")
+	assert.LessOrEqual(t, recorder.Len(), 5)
+	assert.GreaterOrEqual(t, recorder.Len(), 2)
+	assert.Equal(t, "pod4", recorder.events[recorder.Len()-1].Data.Name, "Expected replay to end with the latest event")
+}
+
+func TestRedisStreamEventBus_ReclaimPending(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	server, redisClient := newTestRedisClient(t)
+	deadConsumer := events.NewRedisStreamEventBus(redisClient, 100, "consumer-1")
+	eventBus := events.NewRedisStreamEventBus(redisClient, 100, "consumer-2")
+	recorder := &eventRecorder{}
+
+	_ = redisClient.XGroupCreateMkStream(ctx, ultron.TopicPodObserve, "group-1", "0").Err()
+	_ = deadConsumer.Publish(ctx, ultron.TopicPodObserve, events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Name: "pod1"}))
+	_ = redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{Group: "group-1", Consumer: "consumer-1", Streams: []string{ultron.TopicPodObserve, ">"}, Block: -1}).Err()
+
+	// Act
+	notIdleErr := eventBus.ReclaimPending(ctx, ultron.TopicPodObserve, "group-1", recorder.Handle)
+	notIdleLen := recorder.Len()
+
+	server.SetTime(time.Now().Add(2 * time.Minute))
+
+	err := eventBus.ReclaimPending(ctx, ultron.TopicPodObserve, "group-1", recorder.Handle)
+	pending, _ := redisClient.XPending(ctx, ultron.TopicPodObserve, "group-1").Result()
+
+	// Assert
+	assert.NoError(t, notIdleErr)
+	assert.Equal(t, 0, notIdleLen, "Expected messages within the idle time to stay with their consumer")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, recorder.Len(), "Expected the message of the dead consumer to be reclaimed")
+	assert.Equal(t, int64(0), pending.Count, "Expected the reclaimed message to be acknowledged")
+}
+
+func TestRedisStreamEventBus_ReclaimPendingDeadLetters(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	server, redisClient := newTestRedisClient(t)
+	eventBus := events.NewRedisStreamEventBus(redisClient, 100, "consumer-1")
+	failingRecorder := &eventRecorder{fail: true}
+	deadLetters := &eventRecorder{}
+
+	_ = redisClient.XGroupCreateMkStream(ctx, ultron.TopicPodObserve, "group-1", "0").Err()
+	_ = eventBus.Publish(ctx, ultron.TopicPodObserve, events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Name: "pod1"}))
+	_ = redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{Group: "group-1", Consumer: "consumer-1", Streams: []string{ultron.TopicPodObserve, ">"}, Block: -1}).Err()
+
+	// Act
+	now := time.Now()
+
+	for i := 0; i < 5; i++ {
+		now = now.Add(2 * time.Minute)
+		server.SetTime(now)
+
+		_ = eventBus.ReclaimPending(ctx, ultron.TopicPodObserve, "group-1", failingRecorder.Handle)
+	}
+
+	pending, _ := redisClient.XPending(ctx, ultron.TopicPodObserve, "group-1").Result()
+	err := eventBus.Replay(ctx, events.DeadLetterTopic(ultron.TopicPodObserve), time.Time{}, deadLetters.Handle)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), pending.Count, "Expected the dead-lettered message to be acknowledged")
+	assert.Equal(t, 1, deadLetters.Len(), "Expected the failing message to be dead-lettered")
+	assert.Equal(t, "pod1", deadLetters.events[0].Data.Name)
+}
@@ -2,10 +2,12 @@ package pkg
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"go.uber.org/zap"
@@ -17,16 +19,192 @@ func LoadConfig() (*Config, error) {
 		redisDatabase = 0
 	}
 
+	certificateRenewBefore, err := time.ParseDuration(getEnvWithDefault(EnvServerCertificateRenewBefore, "48h"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerCertificateRenewBefore, err)
+	}
+
+	certificateValidity, err := time.ParseDuration(getEnvWithDefault(EnvServerCertificateValidity, "168h"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerCertificateValidity, err)
+	}
+
+	certificateKeyAlgorithm := KeyAlgorithm(getEnvWithDefault(EnvServerCertificateKeyAlgorithm, string(KeyAlgorithmRsa)))
+	if !certificateKeyAlgorithm.IsValid() {
+		return nil, fmt.Errorf("invalid %s: %s", EnvServerCertificateKeyAlgorithm, certificateKeyAlgorithm)
+	}
+
+	certificateAuthorityValidity, err := time.ParseDuration(getEnvWithDefault(EnvServerCertificateAuthorityValidity, "87600h"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerCertificateAuthorityValidity, err)
+	}
+
+	clientCaReloadInterval, err := getEnvIntervalWithDefault(EnvServerClientCaReloadInterval, "1m")
+	if err != nil {
+		return nil, err
+	}
+
+	localCacheTtl, err := getEnvIntervalWithDefault(EnvServerLocalCacheTtl, "30s")
+	if err != nil {
+		return nil, err
+	}
+
+	nodeObserverInterval, err := getEnvIntervalWithDefault(EnvServerNodeObserverInterval, "1m")
+	if err != nil {
+		return nil, err
+	}
+
+	informerResyncPeriod, err := time.ParseDuration(getEnvWithDefault(EnvServerInformerResyncPeriod, "10m"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerInformerResyncPeriod, err)
+	}
+
+	metricsPollInterval, err := getEnvIntervalWithDefault(EnvServerMetricsPollInterval, "30s")
+	if err != nil {
+		return nil, err
+	}
+
+	computeConfigurationReloadInterval, err := getEnvIntervalWithDefault(EnvServerComputeConfigurationReloadInterval, "5m")
+	if err != nil {
+		return nil, err
+	}
+
+	mutationDryRun, err := strconv.ParseBool(getEnvWithDefault(EnvServerMutationDryRun, "false"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerMutationDryRun, err)
+	}
+
+	mutationPlacementMode := PlacementMode(getEnvWithDefault(EnvServerMutationPlacementMode, string(PlacementModeNodeSelector)))
+	if !mutationPlacementMode.IsValid() {
+		return nil, fmt.Errorf("invalid %s: %s", EnvServerMutationPlacementMode, mutationPlacementMode)
+	}
+
+	mutationSelectorPrecedence := SelectorPrecedence(getEnvWithDefault(EnvServerMutationSelectorPrecedence, string(SelectorPrecedenceWorkload)))
+	if !mutationSelectorPrecedence.IsValid() {
+		return nil, fmt.Errorf("invalid %s: %s", EnvServerMutationSelectorPrecedence, mutationSelectorPrecedence)
+	}
+
+	mutationPreferredCandidates, err := strconv.Atoi(getEnvWithDefault(EnvServerMutationPreferredCandidates, strconv.Itoa(DefaultPreferredCandidates)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerMutationPreferredCandidates, err)
+	}
+
+	admissionTimeout, err := time.ParseDuration(getEnvWithDefault(EnvServerAdmissionTimeout, "10s"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerAdmissionTimeout, err)
+	}
+
+	admissionFailurePolicy := FailurePolicy(getEnvWithDefault(EnvServerAdmissionFailurePolicy, string(FailurePolicyOpen)))
+	if !admissionFailurePolicy.IsValid() {
+		return nil, fmt.Errorf("invalid %s: %s", EnvServerAdmissionFailurePolicy, admissionFailurePolicy)
+	}
+
+	validationEnforce, err := strconv.ParseBool(getEnvWithDefault(EnvServerValidationEnforce, "false"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerValidationEnforce, err)
+	}
+
+	eventBusMaxLen, err := strconv.ParseInt(getEnvWithDefault(EnvServerEventBusMaxLen, strconv.Itoa(DefaultEventBusMaxLen)), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerEventBusMaxLen, err)
+	}
+
+	readinessMaxCacheAge, err := time.ParseDuration(getEnvWithDefault(EnvServerReadinessMaxCacheAge, "15m"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerReadinessMaxCacheAge, err)
+	}
+
+	shutdownTimeout, err := time.ParseDuration(getEnvWithDefault(EnvServerShutdownTimeout, "30s"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerShutdownTimeout, err)
+	}
+
+	tracingInsecure, err := strconv.ParseBool(getEnvWithDefault(EnvServerTracingInsecure, "false"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerTracingInsecure, err)
+	}
+
+	tracingSampleRatio, err := strconv.ParseFloat(getEnvWithDefault(EnvServerTracingSampleRatio, "1"), 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerTracingSampleRatio, err)
+	}
+
+	webhookRegistrationEnabled, err := strconv.ParseBool(getEnvWithDefault(EnvServerWebhookRegistrationEnabled, "false"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerWebhookRegistrationEnabled, err)
+	}
+
+	webhookServicePort, err := strconv.ParseInt(getEnvWithDefault(EnvServerWebhookServicePort, "443"), 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerWebhookServicePort, err)
+	}
+
+	karpenterEnabled, err := strconv.ParseBool(getEnvWithDefault(EnvServerKarpenterEnabled, "false"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", EnvServerKarpenterEnabled, err)
+	}
+
+	weightProfilesReloadInterval, err := getEnvIntervalWithDefault(EnvServerWeightProfilesReloadInterval, "30s")
+	if err != nil {
+		return nil, err
+	}
+
 	return &Config{
-		RedisServerAddress:        os.Getenv(EnvRedisServerAddress),
-		RedisServerPassword:       os.Getenv(EnvRedisServerPassword),
-		RedisServerDatabase:       redisDatabase,
-		ServerAddress:             getEnvWithDefault(EnvServerAddress, ":8443"),
-		CertificateOrganization:   getEnvWithDefault(EnvServerCertificateOrganization, "be-heroes"),
-		CertificateCommonName:     getEnvWithDefault(EnvServerCertificateCommonName, "ultron-service.default.svc"),
-		CertificateDnsNamesCSV:    getEnvWithDefault(EnvServerCertificateDnsNames, "ultron-service.default.svc,ultron-service,localhost"),
-		CertificateIpAddressesCSV: getEnvWithDefault(EnvServerCertificateIpAddresses, "127.0.0.1"),
-		CertificateExportPath:     getEnvWithDefault(EnvServerCertificateExportPath, "ultron_ca_cert.pem"),
+		RedisServerAddress:                 os.Getenv(EnvRedisServerAddress),
+		RedisServerPassword:                os.Getenv(EnvRedisServerPassword),
+		RedisServerDatabase:                redisDatabase,
+		ServerAddress:                      getEnvWithDefault(EnvServerAddress, ":8443"),
+		CertificateOrganization:            getEnvWithDefault(EnvServerCertificateOrganization, "be-heroes"),
+		CertificateCommonName:              getEnvWithDefault(EnvServerCertificateCommonName, "ultron-service.default.svc"),
+		CertificateDnsNamesCSV:             getEnvWithDefault(EnvServerCertificateDnsNames, "ultron-service.default.svc,ultron-service,localhost"),
+		CertificateIpAddressesCSV:          getEnvWithDefault(EnvServerCertificateIpAddresses, "127.0.0.1"),
+		CertificateExportPath:              getEnvWithDefault(EnvServerCertificateExportPath, "ultron_ca_cert.pem"),
+		CertificateSource:                  getEnvWithDefault(EnvServerCertificateSource, CertificateSourceSelfSigned),
+		CertificateLocation:                os.Getenv(EnvServerCertificateLocation),
+		CertificateRenewBefore:             certificateRenewBefore,
+		CertificateValidity:                certificateValidity,
+		CertificateKeyAlgorithm:            certificateKeyAlgorithm,
+		CertificateAuthorityStore:          os.Getenv(EnvServerCertificateAuthorityStore),
+		CertificateAuthorityLocation:       os.Getenv(EnvServerCertificateAuthorityLocation),
+		CertificateAuthorityValidity:       certificateAuthorityValidity,
+		ClientCaSource:                     os.Getenv(EnvServerClientCaSource),
+		ClientCaLocation:                   os.Getenv(EnvServerClientCaLocation),
+		ClientCaReloadInterval:             clientCaReloadInterval,
+		ClientAllowedCommonNamesCSV:        os.Getenv(EnvServerClientAllowedCommonNames),
+		KubernetesConfigPath:               os.Getenv(EnvKubernetesConfig),
+		KubernetesMasterUrl:                fmt.Sprintf("https://%s:%s", os.Getenv(EnvKubernetesServiceHost), os.Getenv(EnvKubernetesServicePort)),
+		LocalCacheTtl:                      localCacheTtl,
+		NodeObserverInterval:               nodeObserverInterval,
+		InformerResyncPeriod:               informerResyncPeriod,
+		MetricsPollInterval:                metricsPollInterval,
+		ComputeConfigurationSource:         os.Getenv(EnvServerComputeConfigurationSource),
+		ComputeConfigurationLocation:       os.Getenv(EnvServerComputeConfigurationLocation),
+		ComputeConfigurationReloadInterval: computeConfigurationReloadInterval,
+		WeightProfile:                      os.Getenv(EnvServerWeightProfile),
+		WeightProfilesPath:                 os.Getenv(EnvServerWeightProfilesPath),
+		WeightProfilesReloadInterval:       weightProfilesReloadInterval,
+		MutationDryRun:                     mutationDryRun,
+		MutationPlacementMode:              mutationPlacementMode,
+		MutationSelectorPrecedence:         mutationSelectorPrecedence,
+		MutationPreferredCandidates:        mutationPreferredCandidates,
+		AdmissionTimeout:                   admissionTimeout,
+		AdmissionFailurePolicy:             admissionFailurePolicy,
+		ValidationEnforce:                  validationEnforce,
+		EventBusMaxLen:                     eventBusMaxLen,
+		ReadinessMaxCacheAge:               readinessMaxCacheAge,
+		ShutdownTimeout:                    shutdownTimeout,
+		TracingEndpoint:                    os.Getenv(EnvServerTracingEndpoint),
+		TracingInsecure:                    tracingInsecure,
+		TracingSampleRatio:                 tracingSampleRatio,
+		WebhookRegistrationEnabled:         webhookRegistrationEnabled,
+		WebhookConfigurationName:           getEnvWithDefault(EnvServerWebhookConfigurationName, "ultron"),
+		WebhookService:                     getEnvWithDefault(EnvServerWebhookService, "default/ultron-service"),
+		WebhookServicePort:                 int32(webhookServicePort),
+		WebhookNamespaceSelector:           getEnvWithDefault(EnvServerWebhookNamespaceSelector, "kubernetes.io/metadata.name notin (kube-system)"),
+		KarpenterEnabled:                   karpenterEnabled,
+		KarpenterNodeClassGroup:            getEnvWithDefault(EnvServerKarpenterNodeClassGroup, "karpenter.k8s.aws"),
+		KarpenterNodeClassKind:             getEnvWithDefault(EnvServerKarpenterNodeClassKind, "EC2NodeClass"),
+		KarpenterNodeClassName:             getEnvWithDefault(EnvServerKarpenterNodeClassName, "default"),
 	}, nil
 }
 
@@ -59,6 +237,21 @@ func getEnvWithDefault(envVar, defaultValue string) string {
 	return value
 }
 
+// getEnvIntervalWithDefault parses a ticker interval, which must be positive as time.NewTicker
+// panics otherwise.
+func getEnvIntervalWithDefault(envVar, defaultValue string) (time.Duration, error) {
+	interval, err := time.ParseDuration(getEnvWithDefault(envVar, defaultValue))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse %s: %w", envVar, err)
+	}
+
+	if interval <= 0 {
+		return 0, fmt.Errorf("invalid %s: %s must be positive", envVar, interval)
+	}
+
+	return interval, nil
+}
+
 func ParseCsvIpAddressString(csv string) []net.IP {
 	var certificateIpAddresses []net.IP
 
@@ -70,14 +70,25 @@ func (m *Mapper) MapPodToWeightedPod(pod *corev1.Pod) (ultron.WeightedPod, error
 	requestedStorageSize := m.GetFloatAnnotationOrDefault(pod.Annotations, ultron.AnnotationStorageSizeGb, ultron.DefaultStorageSizeGB)
 	priority := m.GetPriorityFromAnnotation(pod.Annotations)
 
+	selector := map[string]string{ultron.MetadataName: pod.Name}
+	annotations := map[string]string{
+		ultron.AnnotationDiskType:         requestedDiskType,
+		ultron.AnnotationNetworkType:      requestedNetworkType,
+		ultron.AnnotationWorkloadPriority: priority.String(),
+		ultron.AnnotationStorageSizeGb:    strconv.FormatFloat(requestedStorageSize, 'f', -1, 64),
+	}
+
+	if pod.Namespace != "" {
+		selector[ultron.MetadataNamespace] = pod.Namespace
+	}
+
+	if weightProfile, exists := pod.Annotations[ultron.AnnotationWeightProfile]; exists {
+		annotations[ultron.AnnotationWeightProfile] = weightProfile
+	}
+
 	return ultron.WeightedPod{
-		Selector: map[string]string{ultron.MetadataName: pod.Name},
-		Annotations: map[string]string{
-			ultron.AnnotationDiskType:         requestedDiskType,
-			ultron.AnnotationNetworkType:      requestedNetworkType,
-			ultron.AnnotationWorkloadPriority: priority.String(),
-			ultron.AnnotationStorageSizeGb:    strconv.FormatFloat(requestedStorageSize, 'f', -1, 64),
-		},
+		Selector:    selector,
+		Annotations: annotations,
 		Weights: map[string]float64{
 			ultron.WeightKeyCpuRequested:     totalCPURequest,
 			ultron.WeightKeyCpuLimit:         totalCPULimit,
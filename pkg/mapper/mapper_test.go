@@ -186,3 +186,23 @@ func TestGetPriorityFromAnnotation(t *testing.T) {
 		assert.Equal(t, test.expectedValue, result, fmt.Sprintf("Expected %v, got %v", test.expectedValue, result))
 	}
 }
+
+func TestMapPodToWeightedPod_NamespaceAndWeightProfile(t *testing.T) {
+	mapper := mapper.NewMapper()
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-pod",
+			Namespace:   "batch",
+			Annotations: map[string]string{ultron.AnnotationWeightProfile: "cost-optimized"},
+		},
+	}
+
+	// Act
+	weightedPod, err := mapper.MapPodToWeightedPod(pod)
+
+	// Assert
+	assert.NoError(t, err, "MapPodToWeightedPod should not return an error")
+	assert.Equal(t, "batch", weightedPod.Selector[ultron.MetadataNamespace], "Expected namespace to be part of the selector")
+	assert.Equal(t, "cost-optimized", weightedPod.Annotations[ultron.AnnotationWeightProfile], "Expected weight profile annotation to be copied")
+}
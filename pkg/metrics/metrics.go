@@ -0,0 +1,137 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	Namespace = "ultron"
+
+	HandlerMutation   = "mutation"
+	HandlerValidation = "validation"
+
+	OutcomeAllowed      = "allowed"
+	OutcomeDenied       = "denied"
+	OutcomeFailedClosed = "failed_closed"
+	OutcomeFailedOpen   = "failed_open"
+	OutcomePatched      = "patched"
+	OutcomeSkipped      = "skipped"
+
+	CacheResultError = "error"
+	CacheResultHit   = "hit"
+	CacheResultMiss  = "miss"
+
+	RotationResultError   = "error"
+	RotationResultSuccess = "success"
+
+	ShadowResultMatch    = "match"
+	ShadowResultMismatch = "mismatch"
+)
+
+var (
+	AdmissionRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "admission_requests_total",
+		Help:      "Number of admission requests handled, by handler and outcome.",
+	}, []string{"handler", "outcome"})
+
+	AdmissionRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "admission_request_duration_seconds",
+		Help:      "Latency of admission requests, by handler and outcome.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"handler", "outcome"})
+
+	CandidateNodesEvaluated = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "candidate_nodes_evaluated",
+		Help:      "Number of weighted nodes evaluated per ranking.",
+		Buckets:   []float64{0, 1, 2, 5, 10, 25, 50, 100, 250, 500, 1000},
+	})
+
+	CandidateNodeScore = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: Namespace,
+		Name:      "candidate_node_score",
+		Help:      "Distribution of total scores for eligible candidate nodes.",
+		Buckets:   prometheus.LinearBuckets(-1, 0.25, 13),
+	})
+
+	CacheRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "cache_requests_total",
+		Help:      "Number of cache lookups, by cache key and result.",
+	}, []string{"key", "result"})
+
+	CertificateNotAfter = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: Namespace,
+		Name:      "certificate_not_after_timestamp_seconds",
+		Help:      "Expiry of the serving certificate as a Unix timestamp.",
+	})
+
+	CertificateRotationsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "certificate_rotations_total",
+		Help:      "Number of serving certificate rotations, by result.",
+	}, []string{"result"})
+
+	ComputeConfigurationFallbacksTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "compute_configuration_fallbacks_total",
+		Help:      "Number of placeholder instance types emitted because no weighted node matched.",
+	}, []string{"instance_type"})
+
+	EventDeadLettersTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "event_dead_letters_total",
+		Help:      "Number of events moved to a dead letter topic after repeated handler failures, by topic.",
+	}, []string{"topic"})
+
+	RedisPublishFailuresTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "redis_publish_failures_total",
+		Help:      "Number of failed Redis publishes, by topic.",
+	}, []string{"topic"})
+
+	ShadowPlacementsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: Namespace,
+		Name:      "shadow_placements_total",
+		Help:      "Number of shadow placements compared with the scheduler placement, by result.",
+	}, []string{"result"})
+)
+
+func ObserveAdmission(handler string, outcome string, duration time.Duration) {
+	AdmissionRequestsTotal.WithLabelValues(handler, outcome).Inc()
+	AdmissionRequestDuration.WithLabelValues(handler, outcome).Observe(duration.Seconds())
+}
+
+type candidateRecordingKey struct{}
+
+// WithCandidateRecording marks ctx as an admission whose candidates are recorded by its first ranking,
+// as the same pod is ranked again for preferred placement, validation and explanations.
+func WithCandidateRecording(ctx context.Context) context.Context {
+	return context.WithValue(ctx, candidateRecordingKey{}, &atomic.Bool{})
+}
+
+func ObserveCandidates(ctx context.Context, evaluated int, scores []float64) {
+	recorded, ok := ctx.Value(candidateRecordingKey{}).(*atomic.Bool)
+	if !ok || !recorded.CompareAndSwap(false, true) {
+		return
+	}
+
+	CandidateNodesEvaluated.Observe(float64(evaluated))
+
+	for _, score := range scores {
+		CandidateNodeScore.Observe(score)
+	}
+}
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,79 @@
+package metrics_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+)
+
+func TestObserveAdmission(t *testing.T) {
+	// Arrange
+	counter := metrics.AdmissionRequestsTotal.WithLabelValues(metrics.HandlerMutation, metrics.OutcomePatched)
+	before := testutil.ToFloat64(counter)
+
+	// Act
+	metrics.ObserveAdmission(metrics.HandlerMutation, metrics.OutcomePatched, 10*time.Millisecond)
+
+	// Assert
+	assert.Equal(t, before+1, testutil.ToFloat64(counter), "Expected the admission counter to be incremented")
+}
+
+func TestHandler(t *testing.T) {
+	// Arrange
+	metrics.ObserveAdmission(metrics.HandlerValidation, metrics.OutcomeAllowed, time.Millisecond)
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+
+	// Act
+	metrics.Handler().ServeHTTP(w, req)
+
+	// Assert
+	body, _ := io.ReadAll(w.Result().Body)
+
+	assert.Equal(t, http.StatusOK, w.Result().StatusCode, "Expected status code 200")
+	assert.Contains(t, string(body), `ultron_admission_requests_total{handler="validation",outcome="allowed"}`)
+	assert.Contains(t, string(body), "ultron_admission_request_duration_seconds_bucket")
+}
+
+func TestObserveCandidates_RecordsOncePerAdmission(t *testing.T) {
+	// Arrange
+	before := candidateRankings(t)
+	ctx := metrics.WithCandidateRecording(context.Background())
+
+	// Act
+	metrics.ObserveCandidates(context.Background(), 2, []float64{0.5, 0.25})
+	metrics.ObserveCandidates(ctx, 2, []float64{0.5, 0.25})
+	metrics.ObserveCandidates(ctx, 2, []float64{0.5, 0.25})
+
+	// Assert
+	assert.Equal(t, before+1, candidateRankings(t), "Expected candidates to be recorded once per admission")
+}
+
+func candidateRankings(t *testing.T) float64 {
+	w := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body, _ := io.ReadAll(w.Result().Body)
+
+	for _, line := range strings.Split(string(body), "\n") {
+		if value, ok := strings.CutPrefix(line, "ultron_candidate_nodes_evaluated_count "); ok {
+			count, err := strconv.ParseFloat(value, 64)
+			assert.NoError(t, err, "Expected a numeric sample count")
+
+			return count
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,250 @@
+package observers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	mapper "github.com/be-heroes/ultron/pkg/mapper"
+	services "github.com/be-heroes/ultron/pkg/services"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const nodeEventFlushDelay = time.Second
+
+type INodeObserver interface {
+	Start(ctx context.Context, interval time.Duration) error
+	Observe(ctx context.Context) ([]ultron.WeightedNode, error)
+	ObserveNode(ctx context.Context, node *corev1.Node, metrics map[string]string) (*ultron.WeightedNode, error)
+	HandleClusterEvent(event ultron.ClusterEvent)
+	FlushPendingNodes(ctx context.Context) error
+}
+
+type NodeObserver struct {
+	kubernetesService services.IKubernetesService
+	computeService    services.IComputeService
+	cacheService      services.ICacheService
+	mapper            mapper.IMapper
+	mutex             sync.Mutex
+	pendingMutex      sync.Mutex
+	pendingNodes      map[string]*corev1.Node
+	flushTimer        *time.Timer
+}
+
+func NewNodeObserver(kubernetesService services.IKubernetesService, computeService services.IComputeService, cacheService services.ICacheService, mapper mapper.IMapper) *NodeObserver {
+	return &NodeObserver{
+		kubernetesService: kubernetesService,
+		computeService:    computeService,
+		cacheService:      cacheService,
+		mapper:            mapper,
+		pendingNodes:      map[string]*corev1.Node{},
+	}
+}
+
+func (o *NodeObserver) Start(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := o.Observe(ctx); err != nil {
+			log.Printf("Could not observe nodes: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (o *NodeObserver) Observe(ctx context.Context) ([]ultron.WeightedNode, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	nodes, err := o.kubernetesService.GetNodes(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	nodeMetrics, err := o.kubernetesService.GetNodeMetrics(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	wNodes := []ultron.WeightedNode{}
+
+	for _, node := range nodes {
+		wNode, err := o.ObserveNode(ctx, &node, nodeMetrics[node.Name])
+		if err != nil {
+			log.Printf("Skipping node %s: %v", node.Name, err)
+
+			continue
+		}
+
+		wNodes = append(wNodes, *wNode)
+	}
+
+	if err := o.cacheService.AddCacheItem(ctx, ultron.CacheKeyWeightedNodes, wNodes, 0); err != nil {
+		return nil, err
+	}
+
+	return wNodes, nil
+}
+
+func (o *NodeObserver) ObserveNode(ctx context.Context, node *corev1.Node, metrics map[string]string) (*ultron.WeightedNode, error) {
+	const bytesInGiB = 1024 * 1024 * 1024
+
+	wNode, err := o.mapper.MapNodeToWeightedNode(node)
+	if err != nil {
+		return nil, err
+	}
+
+	if cpuUsage, err := strconv.ParseFloat(metrics[ultron.WeightKeyCpuUsage], 64); err == nil {
+		wNode.Weights[ultron.WeightKeyCpuUsage] = cpuUsage
+	}
+
+	if memoryUsage, err := strconv.ParseFloat(metrics[ultron.WeightKeyMemoryUsage], 64); err == nil {
+		wNode.Weights[ultron.WeightKeyMemoryUsage] = memoryUsage / float64(bytesInGiB)
+	}
+
+	if medianPrice, err := o.computeService.CalculateWeightedNodeMedianPrice(ctx, &wNode); err == nil {
+		wNode.Weights[ultron.WeightKeyPriceMedian] = medianPrice
+	}
+
+	interuptionRate, err := o.computeService.GetInteruptionRateForWeightedNode(ctx, &wNode)
+	if err == nil && interuptionRate != nil {
+		wNode.InterruptionRate = *interuptionRate
+	} else {
+		wNode.InterruptionRate = ultron.WeightedInteruptionRate{Weight: -1}
+	}
+
+	latencyRate, err := o.computeService.GetLatencyRateForWeightedNode(ctx, &wNode)
+	if err == nil && latencyRate != nil {
+		wNode.LatencyRate = *latencyRate
+	} else {
+		wNode.LatencyRate = ultron.WeightedLatencyRate{Weight: -1}
+	}
+
+	return &wNode, nil
+}
+
+// HandleClusterEvent queues node changes and folds them into the cached weighted nodes in a single
+// write shortly after the first one, so informer syncs and bursts of updates stay cheap.
+func (o *NodeObserver) HandleClusterEvent(event ultron.ClusterEvent) {
+	if event.Kind != ultron.ClusterEventKindNode {
+		return
+	}
+
+	node, ok := event.Object.(*corev1.Node)
+	if !ok {
+		return
+	}
+
+	if event.Type == ultron.ClusterEventTypeUpdated {
+		if oldNode, ok := event.OldObject.(*corev1.Node); ok && !nodeWeightsChanged(oldNode, node) {
+			return
+		}
+	}
+
+	hostname := node.Labels[ultron.LabelHostName]
+
+	o.pendingMutex.Lock()
+	defer o.pendingMutex.Unlock()
+
+	if event.Type == ultron.ClusterEventTypeDeleted {
+		o.pendingNodes[hostname] = nil
+	} else {
+		o.pendingNodes[hostname] = node
+	}
+
+	if o.flushTimer == nil {
+		o.flushTimer = time.AfterFunc(nodeEventFlushDelay, func() {
+			if err := o.FlushPendingNodes(context.Background()); err != nil {
+				log.Printf("Could not update weighted nodes: %v", err)
+			}
+		})
+	}
+}
+
+func (o *NodeObserver) FlushPendingNodes(ctx context.Context) error {
+	o.pendingMutex.Lock()
+	pendingNodes := o.pendingNodes
+	o.pendingNodes = map[string]*corev1.Node{}
+
+	if o.flushTimer != nil {
+		o.flushTimer.Stop()
+		o.flushTimer = nil
+	}
+
+	o.pendingMutex.Unlock()
+
+	if len(pendingNodes) == 0 {
+		return nil
+	}
+
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	// Never write back a partial list; the next full observation picks the changes up instead.
+	wNodes, err := o.cacheService.GetWeightedNodes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get weighted nodes: %w", err)
+	}
+
+	updatedNodes := []ultron.WeightedNode{}
+
+	for _, wNode := range wNodes {
+		if _, pending := pendingNodes[wNode.Selector[ultron.LabelHostName]]; !pending {
+			updatedNodes = append(updatedNodes, wNode)
+		}
+	}
+
+	hostnames := make([]string, 0, len(pendingNodes))
+	for hostname, node := range pendingNodes {
+		if node != nil {
+			hostnames = append(hostnames, hostname)
+		}
+	}
+
+	sort.Strings(hostnames)
+
+	if len(hostnames) > 0 {
+		nodeMetrics, err := o.kubernetesService.GetNodeMetrics(ctx, metav1.ListOptions{})
+		if err != nil {
+			log.Printf("Could not get node metrics: %v", err)
+		}
+
+		for _, hostname := range hostnames {
+			node := pendingNodes[hostname]
+
+			wNode, err := o.ObserveNode(ctx, node, nodeMetrics[node.Name])
+			if err != nil {
+				log.Printf("Skipping node %s: %v", node.Name, err)
+
+				continue
+			}
+
+			updatedNodes = append(updatedNodes, *wNode)
+		}
+	}
+
+	return o.cacheService.AddCacheItem(ctx, ultron.CacheKeyWeightedNodes, updatedNodes, 0)
+}
+
+// nodeWeightsChanged ignores status-only updates such as kubelet heartbeats, which carry nothing
+// the mapper weighs.
+func nodeWeightsChanged(oldNode *corev1.Node, newNode *corev1.Node) bool {
+	return !equality.Semantic.DeepEqual(oldNode.Labels, newNode.Labels) ||
+		!equality.Semantic.DeepEqual(oldNode.Annotations, newNode.Annotations) ||
+		!equality.Semantic.DeepEqual(oldNode.Status.Allocatable, newNode.Status.Allocatable) ||
+		!equality.Semantic.DeepEqual(oldNode.Status.Capacity, newNode.Status.Capacity)
+}
@@ -0,0 +1,235 @@
+package observers_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/be-heroes/ultron/mocks"
+	ultron "github.com/be-heroes/ultron/pkg"
+	observers "github.com/be-heroes/ultron/pkg/observers"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestObserve_Success(t *testing.T) {
+	// Arrange
+	mockKubernetesService := new(mocks.IKubernetesService)
+	mockComputeService := new(mocks.IComputeService)
+	mockCacheService := new(mocks.ICacheService)
+	mockMapper := new(mocks.IMapper)
+
+	observer := observers.NewNodeObserver(mockKubernetesService, mockComputeService, mockCacheService, mockMapper)
+
+	nodes := []corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "node2"}},
+	}
+
+	mockKubernetesService.On("GetNodes", mock.Anything, metav1.ListOptions{}).Return(nodes, nil)
+	mockKubernetesService.On("GetNodeMetrics", mock.Anything, metav1.ListOptions{}).Return(map[string]map[string]string{
+		"node1": {
+			ultron.WeightKeyCpuUsage:    "0.500",
+			ultron.WeightKeyMemoryUsage: "1073741824",
+		},
+	}, nil)
+
+	mockMapper.On("MapNodeToWeightedNode", &nodes[0]).Return(ultron.WeightedNode{
+		Annotations: map[string]string{ultron.AnnotationInstanceType: "t3.medium"},
+		Selector:    map[string]string{ultron.LabelHostName: "node1"},
+		Weights:     map[string]float64{},
+	}, nil)
+	mockMapper.On("MapNodeToWeightedNode", &nodes[1]).Return(ultron.WeightedNode{}, fmt.Errorf("missing required label"))
+
+	mockComputeService.On("CalculateWeightedNodeMedianPrice", mock.Anything, mock.AnythingOfType("*pkg.WeightedNode")).Return(0.25, nil)
+	mockComputeService.On("GetInteruptionRateForWeightedNode", mock.Anything, mock.AnythingOfType("*pkg.WeightedNode")).Return(&ultron.WeightedInteruptionRate{Weight: 0.1}, nil)
+	mockComputeService.On("GetLatencyRateForWeightedNode", mock.Anything, mock.AnythingOfType("*pkg.WeightedNode")).Return(nil, nil)
+
+	mockCacheService.On("AddCacheItem", mock.Anything, ultron.CacheKeyWeightedNodes, mock.AnythingOfType("[]pkg.WeightedNode"), mock.Anything).Return(nil)
+
+	// Act
+	wNodes, err := observer.Observe(context.Background())
+
+	// Assert
+	assert.NoError(t, err, "Observe should not return an error")
+	assert.Len(t, wNodes, 1, "Expected nodes failing to map to be skipped")
+	assert.Equal(t, 0.5, wNodes[0].Weights[ultron.WeightKeyCpuUsage], "Expected CPU usage to be 0.5")
+	assert.Equal(t, 1.0, wNodes[0].Weights[ultron.WeightKeyMemoryUsage], "Expected memory usage to be 1GiB")
+	assert.Equal(t, 0.25, wNodes[0].Weights[ultron.WeightKeyPriceMedian], "Expected median price to be 0.25")
+	assert.Equal(t, 0.1, wNodes[0].InterruptionRate.Weight, "Expected interruption rate to be 0.1")
+	assert.Equal(t, -1.0, wNodes[0].LatencyRate.Weight, "Expected missing latency rate to be -1")
+
+	mockKubernetesService.AssertExpectations(t)
+	mockCacheService.AssertExpectations(t)
+}
+
+func TestObserve_GetNodesFailure(t *testing.T) {
+	// Arrange
+	mockKubernetesService := new(mocks.IKubernetesService)
+	mockCacheService := new(mocks.ICacheService)
+
+	observer := observers.NewNodeObserver(mockKubernetesService, new(mocks.IComputeService), mockCacheService, new(mocks.IMapper))
+
+	mockKubernetesService.On("GetNodes", mock.Anything, metav1.ListOptions{}).Return(nil, fmt.Errorf("connection refused"))
+
+	// Act
+	_, err := observer.Observe(context.Background())
+
+	// Assert
+	assert.Error(t, err, "Expected an error when nodes cannot be listed")
+	mockCacheService.AssertNotCalled(t, "AddCacheItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestObserveNode_ComputeServiceFailure(t *testing.T) {
+	// Arrange
+	mockComputeService := new(mocks.IComputeService)
+	mockMapper := new(mocks.IMapper)
+
+	observer := observers.NewNodeObserver(new(mocks.IKubernetesService), mockComputeService, new(mocks.ICacheService), mockMapper)
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+
+	mockMapper.On("MapNodeToWeightedNode", node).Return(ultron.WeightedNode{Weights: map[string]float64{}}, nil)
+	mockComputeService.On("CalculateWeightedNodeMedianPrice", mock.Anything, mock.AnythingOfType("*pkg.WeightedNode")).Return(0.0, fmt.Errorf("key not found"))
+	mockComputeService.On("GetInteruptionRateForWeightedNode", mock.Anything, mock.AnythingOfType("*pkg.WeightedNode")).Return(nil, fmt.Errorf("key not found"))
+	mockComputeService.On("GetLatencyRateForWeightedNode", mock.Anything, mock.AnythingOfType("*pkg.WeightedNode")).Return(nil, fmt.Errorf("key not found"))
+
+	// Act
+	wNode, err := observer.ObserveNode(context.Background(), node, nil)
+
+	// Assert
+	assert.NoError(t, err, "ObserveNode should tolerate missing compute configurations")
+	assert.Equal(t, 0.0, wNode.Weights[ultron.WeightKeyPriceMedian], "Expected median price to be 0")
+	assert.Equal(t, -1.0, wNode.InterruptionRate.Weight, "Expected interruption rate to be -1")
+	assert.Equal(t, -1.0, wNode.LatencyRate.Weight, "Expected latency rate to be -1")
+}
+
+func TestHandleClusterEvent_NodeUpdated(t *testing.T) {
+	// Arrange
+	mockKubernetesService := new(mocks.IKubernetesService)
+	mockComputeService := new(mocks.IComputeService)
+	mockCacheService := new(mocks.ICacheService)
+	mockMapper := new(mocks.IMapper)
+
+	observer := observers.NewNodeObserver(mockKubernetesService, mockComputeService, mockCacheService, mockMapper)
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{ultron.LabelHostName: "node1"}}}
+
+	mockCacheService.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{
+		{Selector: map[string]string{ultron.LabelHostName: "node1"}, Weights: map[string]float64{ultron.WeightKeyCpuAvailable: 1}},
+		{Selector: map[string]string{ultron.LabelHostName: "node2"}, Weights: map[string]float64{ultron.WeightKeyCpuAvailable: 2}},
+	}, nil)
+	mockKubernetesService.On("GetNodeMetrics", mock.Anything, metav1.ListOptions{}).Return(map[string]map[string]string{}, nil)
+	mockMapper.On("MapNodeToWeightedNode", node).Return(ultron.WeightedNode{
+		Selector: map[string]string{ultron.LabelHostName: "node1"},
+		Weights:  map[string]float64{ultron.WeightKeyCpuAvailable: 4},
+	}, nil)
+	mockComputeService.On("CalculateWeightedNodeMedianPrice", mock.Anything, mock.AnythingOfType("*pkg.WeightedNode")).Return(0.0, nil)
+	mockComputeService.On("GetInteruptionRateForWeightedNode", mock.Anything, mock.AnythingOfType("*pkg.WeightedNode")).Return(nil, nil)
+	mockComputeService.On("GetLatencyRateForWeightedNode", mock.Anything, mock.AnythingOfType("*pkg.WeightedNode")).Return(nil, nil)
+	mockCacheService.On("AddCacheItem", mock.Anything, ultron.CacheKeyWeightedNodes, mock.MatchedBy(func(wNodes []ultron.WeightedNode) bool {
+		return len(wNodes) == 2 && wNodes[0].Selector[ultron.LabelHostName] == "node2" && wNodes[1].Weights[ultron.WeightKeyCpuAvailable] == 4
+	}), mock.Anything).Return(nil)
+
+	// Act
+	observer.HandleClusterEvent(ultron.ClusterEvent{Type: ultron.ClusterEventTypeUpdated, Kind: ultron.ClusterEventKindNode, Object: node})
+	err := observer.FlushPendingNodes(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	mockCacheService.AssertExpectations(t)
+}
+
+func TestHandleClusterEvent_NodeDeleted(t *testing.T) {
+	// Arrange
+	mockCacheService := new(mocks.ICacheService)
+
+	observer := observers.NewNodeObserver(new(mocks.IKubernetesService), new(mocks.IComputeService), mockCacheService, new(mocks.IMapper))
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{ultron.LabelHostName: "node1"}}}
+
+	mockCacheService.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{
+		{Selector: map[string]string{ultron.LabelHostName: "node1"}},
+	}, nil)
+	mockCacheService.On("AddCacheItem", mock.Anything, ultron.CacheKeyWeightedNodes, []ultron.WeightedNode{}, mock.Anything).Return(nil)
+
+	// Act
+	observer.HandleClusterEvent(ultron.ClusterEvent{Type: ultron.ClusterEventTypeDeleted, Kind: ultron.ClusterEventKindNode, Object: node})
+	err := observer.FlushPendingNodes(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	mockCacheService.AssertExpectations(t)
+}
+
+func TestHandleClusterEvent_IgnoresHeartbeat(t *testing.T) {
+	// Arrange
+	mockCacheService := new(mocks.ICacheService)
+
+	observer := observers.NewNodeObserver(new(mocks.IKubernetesService), new(mocks.IComputeService), mockCacheService, new(mocks.IMapper))
+
+	oldNode := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", ResourceVersion: "1", Labels: map[string]string{ultron.LabelHostName: "node1"}}}
+	node := oldNode.DeepCopy()
+	node.ResourceVersion = "2"
+	node.Status.Conditions = []corev1.NodeCondition{{Type: corev1.NodeReady, Status: corev1.ConditionTrue, LastHeartbeatTime: metav1.Now()}}
+
+	// Act
+	observer.HandleClusterEvent(ultron.ClusterEvent{Type: ultron.ClusterEventTypeUpdated, Kind: ultron.ClusterEventKindNode, Object: node, OldObject: oldNode})
+	err := observer.FlushPendingNodes(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	mockCacheService.AssertNotCalled(t, "GetWeightedNodes", mock.Anything)
+	mockCacheService.AssertNotCalled(t, "AddCacheItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleClusterEvent_KeepsCacheOnReadFailure(t *testing.T) {
+	// Arrange
+	mockCacheService := new(mocks.ICacheService)
+
+	observer := observers.NewNodeObserver(new(mocks.IKubernetesService), new(mocks.IComputeService), mockCacheService, new(mocks.IMapper))
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{ultron.LabelHostName: "node1"}}}
+
+	mockCacheService.On("GetWeightedNodes", mock.Anything).Return(nil, fmt.Errorf("connection reset"))
+
+	// Act
+	observer.HandleClusterEvent(ultron.ClusterEvent{Type: ultron.ClusterEventTypeDeleted, Kind: ultron.ClusterEventKindNode, Object: node})
+	err := observer.FlushPendingNodes(context.Background())
+
+	// Assert
+	assert.Error(t, err)
+	mockCacheService.AssertNotCalled(t, "AddCacheItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleClusterEvent_BatchesNodeChanges(t *testing.T) {
+	// Arrange
+	mockCacheService := new(mocks.ICacheService)
+
+	observer := observers.NewNodeObserver(new(mocks.IKubernetesService), new(mocks.IComputeService), mockCacheService, new(mocks.IMapper))
+
+	mockCacheService.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{
+		{Selector: map[string]string{ultron.LabelHostName: "node1"}},
+		{Selector: map[string]string{ultron.LabelHostName: "node2"}},
+		{Selector: map[string]string{ultron.LabelHostName: "node3"}},
+	}, nil).Once()
+	mockCacheService.On("AddCacheItem", mock.Anything, ultron.CacheKeyWeightedNodes, []ultron.WeightedNode{
+		{Selector: map[string]string{ultron.LabelHostName: "node3"}},
+	}, mock.Anything).Return(nil).Once()
+
+	// Act
+	for _, name := range []string{"node1", "node2"} {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{ultron.LabelHostName: name}}}
+		observer.HandleClusterEvent(ultron.ClusterEvent{Type: ultron.ClusterEventTypeDeleted, Kind: ultron.ClusterEventKindNode, Object: node})
+	}
+
+	err := observer.FlushPendingNodes(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	mockCacheService.AssertExpectations(t)
+}
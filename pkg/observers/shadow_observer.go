@@ -0,0 +1,96 @@
+package observers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"sync/atomic"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+	services "github.com/be-heroes/ultron/pkg/services"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+type ShadowStats struct {
+	Matches    int64 `json:"matches"`
+	Mismatches int64 `json:"mismatches"`
+}
+
+type IShadowObserver interface {
+	HandleClusterEvent(event ultron.ClusterEvent)
+	Stats() ShadowStats
+}
+
+type ShadowObserver struct {
+	kubernetesService services.IKubernetesService
+	matches           atomic.Int64
+	mismatches        atomic.Int64
+}
+
+func NewShadowObserver(kubernetesService services.IKubernetesService) *ShadowObserver {
+	return &ShadowObserver{
+		kubernetesService: kubernetesService,
+	}
+}
+
+func (o *ShadowObserver) HandleClusterEvent(event ultron.ClusterEvent) {
+	if event.Kind != ultron.ClusterEventKindPod || event.Type != ultron.ClusterEventTypeUpdated {
+		return
+	}
+
+	pod, ok := event.Object.(*corev1.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return
+	}
+
+	oldPod, ok := event.OldObject.(*corev1.Pod)
+	if !ok || oldPod.Spec.NodeName != "" {
+		return
+	}
+
+	shadowSelector, exists := pod.Annotations[ultron.AnnotationShadowSelector]
+	if !exists {
+		return
+	}
+
+	var selector map[string]string
+	if err := json.Unmarshal([]byte(shadowSelector), &selector); err != nil {
+		log.Printf("Could not parse shadow selector for pod %s/%s: %v", pod.Namespace, pod.Name, err)
+
+		return
+	}
+
+	nodes, err := o.kubernetesService.GetNodes(context.Background(), metav1.ListOptions{LabelSelector: labels.SelectorFromSet(selector).String()})
+	if err != nil {
+		log.Printf("Could not list nodes for shadow selector of pod %s/%s: %v", pod.Namespace, pod.Name, err)
+
+		return
+	}
+
+	for _, node := range nodes {
+		if node.Name == pod.Spec.NodeName {
+			o.matches.Add(1)
+			metrics.ShadowPlacementsTotal.WithLabelValues(metrics.ShadowResultMatch).Inc()
+
+			log.Printf("Shadow placement for pod %s/%s matches scheduler placement on node %s", pod.Namespace, pod.Name, pod.Spec.NodeName)
+
+			return
+		}
+	}
+
+	o.mismatches.Add(1)
+	metrics.ShadowPlacementsTotal.WithLabelValues(metrics.ShadowResultMismatch).Inc()
+
+	log.Printf("Shadow placement for pod %s/%s differs from scheduler placement on node %s", pod.Namespace, pod.Name, pod.Spec.NodeName)
+}
+
+func (o *ShadowObserver) Stats() ShadowStats {
+	return ShadowStats{
+		Matches:    o.matches.Load(),
+		Mismatches: o.mismatches.Load(),
+	}
+}
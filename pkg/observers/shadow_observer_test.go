@@ -0,0 +1,87 @@
+package observers_test
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/be-heroes/ultron/mocks"
+	ultron "github.com/be-heroes/ultron/pkg"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+	observers "github.com/be-heroes/ultron/pkg/observers"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newShadowPodEvent(nodeName string) ultron.ClusterEvent {
+	oldPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pod1",
+			Namespace:   "default",
+			Annotations: map[string]string{ultron.AnnotationShadowSelector: `{"pool":"a"}`},
+		},
+	}
+	pod := oldPod.DeepCopy()
+	pod.Spec.NodeName = nodeName
+
+	return ultron.ClusterEvent{Type: ultron.ClusterEventTypeUpdated, Kind: ultron.ClusterEventKindPod, Object: pod, OldObject: oldPod}
+}
+
+func TestShadowObserver_Match(t *testing.T) {
+	// Arrange
+	mockKubernetesService := new(mocks.IKubernetesService)
+	observer := observers.NewShadowObserver(mockKubernetesService)
+
+	mockKubernetesService.On("GetNodes", mock.Anything, metav1.ListOptions{LabelSelector: "pool=a"}).Return([]corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+	}, nil)
+
+	counter := metrics.ShadowPlacementsTotal.WithLabelValues(metrics.ShadowResultMatch)
+	before := testutil.ToFloat64(counter)
+
+	// Act
+	observer.HandleClusterEvent(newShadowPodEvent("node1"))
+
+	// Assert
+	assert.Equal(t, observers.ShadowStats{Matches: 1}, observer.Stats())
+	assert.Equal(t, before+1, testutil.ToFloat64(counter), "Expected the shadow match to be counted")
+}
+
+func TestShadowObserver_Mismatch(t *testing.T) {
+	// Arrange
+	mockKubernetesService := new(mocks.IKubernetesService)
+	observer := observers.NewShadowObserver(mockKubernetesService)
+
+	mockKubernetesService.On("GetNodes", mock.Anything, metav1.ListOptions{LabelSelector: "pool=a"}).Return([]corev1.Node{
+		{ObjectMeta: metav1.ObjectMeta{Name: "node1"}},
+	}, nil)
+
+	counter := metrics.ShadowPlacementsTotal.WithLabelValues(metrics.ShadowResultMismatch)
+	before := testutil.ToFloat64(counter)
+
+	// Act
+	observer.HandleClusterEvent(newShadowPodEvent("node2"))
+
+	// Assert
+	assert.Equal(t, observers.ShadowStats{Mismatches: 1}, observer.Stats())
+	assert.Equal(t, before+1, testutil.ToFloat64(counter), "Expected the shadow mismatch to be counted")
+}
+
+func TestShadowObserver_IgnoresPodsWithoutShadowSelector(t *testing.T) {
+	// Arrange
+	mockKubernetesService := new(mocks.IKubernetesService)
+	observer := observers.NewShadowObserver(mockKubernetesService)
+
+	event := newShadowPodEvent("node1")
+	delete(event.Object.(*corev1.Pod).Annotations, ultron.AnnotationShadowSelector)
+
+	// Act
+	observer.HandleClusterEvent(event)
+
+	// Assert
+	assert.Equal(t, observers.ShadowStats{}, observer.Stats())
+	mockKubernetesService.AssertNotCalled(t, "GetNodes", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,212 @@
+package provisioners
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+const (
+	karpenterApiVersion       = "karpenter.sh/v1"
+	karpenterLabelCapacity    = "karpenter.sh/capacity-type"
+	karpenterCapacityOnDemand = "on-demand"
+	karpenterCapacitySpot     = "spot"
+)
+
+var NodePoolResource = schema.GroupVersionResource{Group: "karpenter.sh", Version: "v1", Resource: "nodepools"}
+
+type IProvisioner interface {
+	Provision(ctx context.Context, wPod *ultron.WeightedPod, computeConfiguration *ultron.ComputeConfiguration) error
+}
+
+type KarpenterNodeClassRef struct {
+	Group string
+	Kind  string
+	Name  string
+}
+
+type KarpenterProvisioner struct {
+	dynamicClient dynamic.Interface
+	nodeClassRef  KarpenterNodeClassRef
+}
+
+func NewKarpenterProvisioner(dynamicClient dynamic.Interface, nodeClassRef KarpenterNodeClassRef) *KarpenterProvisioner {
+	return &KarpenterProvisioner{
+		dynamicClient: dynamicClient,
+		nodeClassRef:  nodeClassRef,
+	}
+}
+
+func (p *KarpenterProvisioner) Provision(ctx context.Context, wPod *ultron.WeightedPod, computeConfiguration *ultron.ComputeConfiguration) error {
+	if computeConfiguration == nil {
+		return fmt.Errorf("missing compute configuration for pod %s", wPod.Selector[ultron.MetadataName])
+	}
+
+	if err := p.ensureNodePool(ctx, computeConfiguration); err != nil {
+		return fmt.Errorf("failed to ensure node pool: %w", err)
+	}
+
+	return nil
+}
+
+// ensureNodePool only widens the node pool to the matched instance type rather than creating node
+// claims, as Karpenter bin-packs the pending pods onto node claims it creates and consolidates itself.
+// Nodes carry the compute type label that fallback placements select on.
+func (p *KarpenterProvisioner) ensureNodePool(ctx context.Context, computeConfiguration *ultron.ComputeConfiguration) error {
+	name := nodePoolName(computeConfiguration.ComputeType)
+
+	var instanceTypes []string
+	if computeConfiguration.Identifier != nil && strings.TrimSpace(*computeConfiguration.Identifier) != "" {
+		instanceTypes = append(instanceTypes, strings.TrimSpace(*computeConfiguration.Identifier))
+	}
+
+	existing, err := p.dynamicClient.Resource(NodePoolResource).Get(ctx, name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		_, err = p.dynamicClient.Resource(NodePoolResource).Create(ctx, p.newNodePool(name, computeConfiguration.ComputeType, instanceTypes), metav1.CreateOptions{})
+
+		return err
+	}
+
+	if err != nil {
+		return err
+	}
+
+	existingInstanceTypes, err := nodePoolInstanceTypes(existing)
+	if err != nil {
+		return err
+	}
+
+	// A pool without instance type requirements already admits every instance type.
+	if len(existingInstanceTypes) > 0 && len(instanceTypes) > 0 && !slices.Contains(existingInstanceTypes, instanceTypes[0]) {
+		instanceTypes = append(existingInstanceTypes, instanceTypes...)
+		sort.Strings(instanceTypes)
+	} else {
+		instanceTypes = existingInstanceTypes
+	}
+
+	computeType, _, err := unstructured.NestedString(existing.Object, "spec", "template", "metadata", "labels", ultron.LabelComputeType)
+	if err != nil {
+		return err
+	}
+
+	if computeType == string(computeConfiguration.ComputeType) && slices.Equal(instanceTypes, existingInstanceTypes) {
+		return nil
+	}
+
+	updated := p.newNodePool(name, computeConfiguration.ComputeType, instanceTypes)
+	updated.SetResourceVersion(existing.GetResourceVersion())
+
+	_, err = p.dynamicClient.Resource(NodePoolResource).Update(ctx, updated, metav1.UpdateOptions{})
+
+	return err
+}
+
+func (p *KarpenterProvisioner) newNodePool(name string, computeType ultron.ComputeType, instanceTypes []string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": karpenterApiVersion,
+			"kind":       "NodePool",
+			"metadata": map[string]interface{}{
+				"name": name,
+				"labels": map[string]interface{}{
+					ultron.AnnotationManaged: "true",
+				},
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{
+							ultron.AnnotationManaged: "true",
+							ultron.LabelComputeType:  string(computeType),
+						},
+					},
+					"spec": map[string]interface{}{
+						"nodeClassRef": p.newNodeClassRef(),
+						"requirements": newRequirements(computeType, instanceTypes),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (p *KarpenterProvisioner) newNodeClassRef() map[string]interface{} {
+	return map[string]interface{}{
+		"group": p.nodeClassRef.Group,
+		"kind":  p.nodeClassRef.Kind,
+		"name":  p.nodeClassRef.Name,
+	}
+}
+
+func newRequirements(computeType ultron.ComputeType, instanceTypes []string) []interface{} {
+	capacityType := karpenterCapacityOnDemand
+	if computeType == ultron.ComputeTypeEphemeral {
+		capacityType = karpenterCapacitySpot
+	}
+
+	requirements := []interface{}{
+		map[string]interface{}{
+			"key":      karpenterLabelCapacity,
+			"operator": "In",
+			"values":   []interface{}{capacityType},
+		},
+	}
+
+	if len(instanceTypes) == 0 {
+		return requirements
+	}
+
+	values := make([]interface{}, 0, len(instanceTypes))
+	for _, instanceType := range instanceTypes {
+		values = append(values, instanceType)
+	}
+
+	return append(requirements, map[string]interface{}{
+		"key":      ultron.LabelInstanceType,
+		"operator": "In",
+		"values":   values,
+	})
+}
+
+func nodePoolInstanceTypes(nodePool *unstructured.Unstructured) ([]string, error) {
+	requirements, _, err := unstructured.NestedSlice(nodePool.Object, "spec", "template", "spec", "requirements")
+	if err != nil {
+		return nil, err
+	}
+
+	var instanceTypes []string
+
+	for _, requirement := range requirements {
+		requirementMap, ok := requirement.(map[string]interface{})
+		if !ok || requirementMap["key"] != ultron.LabelInstanceType {
+			continue
+		}
+
+		values, _, err := unstructured.NestedStringSlice(requirementMap, "values")
+		if err != nil {
+			return nil, err
+		}
+
+		instanceTypes = append(instanceTypes, values...)
+	}
+
+	return instanceTypes, nil
+}
+
+func nodePoolName(computeType ultron.ComputeType) string {
+	if computeType == ultron.ComputeTypeDurable {
+		return "ultron-durable"
+	}
+
+	return "ultron-ephemeral"
+}
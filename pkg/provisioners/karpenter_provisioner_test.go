@@ -0,0 +1,117 @@
+package provisioners_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	provisioners "github.com/be-heroes/ultron/pkg/provisioners"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func stringPtr(s string) *string { return &s }
+
+func newFakeDynamicClient() *dynamicfake.FakeDynamicClient {
+	return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		provisioners.NodePoolResource: "NodePoolList",
+	})
+}
+
+func newWeightedPod(name string) *ultron.WeightedPod {
+	return &ultron.WeightedPod{
+		Selector: map[string]string{ultron.MetadataName: name, ultron.MetadataNamespace: "default"},
+		Weights: map[string]float64{
+			ultron.WeightKeyCpuRequested:    0.5,
+			ultron.WeightKeyMemoryRequested: 1073741824,
+		},
+	}
+}
+
+func TestProvision_CreatesNodePool(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dynamicClient := newFakeDynamicClient()
+	provisioner := provisioners.NewKarpenterProvisioner(dynamicClient, provisioners.KarpenterNodeClassRef{Group: "karpenter.k8s.aws", Kind: "EC2NodeClass", Name: "default"})
+
+	computeConfiguration := &ultron.ComputeConfiguration{Identifier: stringPtr("t3.medium"), ComputeType: ultron.ComputeTypeDurable}
+
+	// Act
+	err := provisioner.Provision(ctx, newWeightedPod("pod1"), computeConfiguration)
+
+	// Assert
+	assert.NoError(t, err, "Provision should not return an error")
+
+	nodePool, err := dynamicClient.Resource(provisioners.NodePoolResource).Get(ctx, "ultron-durable", metav1.GetOptions{})
+	assert.NoError(t, err, "Expected node pool to be created")
+
+	requirements, _, _ := unstructured.NestedSlice(nodePool.Object, "spec", "template", "spec", "requirements")
+	assert.Equal(t, []interface{}{"on-demand"}, requirements[0].(map[string]interface{})["values"])
+	assert.Equal(t, []interface{}{"t3.medium"}, requirements[1].(map[string]interface{})["values"])
+
+	assert.Equal(t, "true", nodePool.GetLabels()[ultron.AnnotationManaged])
+
+	computeType, _, _ := unstructured.NestedString(nodePool.Object, "spec", "template", "metadata", "labels", ultron.LabelComputeType)
+	assert.Equal(t, string(ultron.ComputeTypeDurable), computeType, "Expected nodes to carry the label fallback placements select")
+}
+
+func TestProvision_WithoutIdentifierLeavesInstanceTypesOpen(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dynamicClient := newFakeDynamicClient()
+	provisioner := provisioners.NewKarpenterProvisioner(dynamicClient, provisioners.KarpenterNodeClassRef{Name: "default"})
+
+	// Act
+	err := provisioner.Provision(ctx, newWeightedPod("pod1"), &ultron.ComputeConfiguration{ComputeType: ultron.ComputeTypeDurable})
+
+	// Assert
+	assert.NoError(t, err)
+
+	nodePool, err := dynamicClient.Resource(provisioners.NodePoolResource).Get(ctx, "ultron-durable", metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	requirements, _, _ := unstructured.NestedSlice(nodePool.Object, "spec", "template", "spec", "requirements")
+	assert.Len(t, requirements, 1, "Expected no placeholder instance type to be requested")
+	assert.Equal(t, "karpenter.sh/capacity-type", requirements[0].(map[string]interface{})["key"])
+}
+
+func TestProvision_UpdatesExistingNodePool(t *testing.T) {
+	// Arrange
+	ctx := context.Background()
+	dynamicClient := newFakeDynamicClient()
+	provisioner := provisioners.NewKarpenterProvisioner(dynamicClient, provisioners.KarpenterNodeClassRef{Name: "default"})
+
+	// Act
+	firstErr := provisioner.Provision(ctx, newWeightedPod("pod1"), &ultron.ComputeConfiguration{Identifier: stringPtr("m5.large"), ComputeType: ultron.ComputeTypeEphemeral})
+	secondErr := provisioner.Provision(ctx, newWeightedPod("pod2"), &ultron.ComputeConfiguration{Identifier: stringPtr("c5.large"), ComputeType: ultron.ComputeTypeEphemeral})
+	repeatErr := provisioner.Provision(ctx, newWeightedPod("pod2"), &ultron.ComputeConfiguration{Identifier: stringPtr("c5.large"), ComputeType: ultron.ComputeTypeEphemeral})
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.NoError(t, repeatErr, "Expected provisioning to be idempotent")
+
+	nodePool, err := dynamicClient.Resource(provisioners.NodePoolResource).Get(ctx, "ultron-ephemeral", metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	requirements, _, _ := unstructured.NestedSlice(nodePool.Object, "spec", "template", "spec", "requirements")
+	assert.Equal(t, []interface{}{"spot"}, requirements[0].(map[string]interface{})["values"])
+	assert.Equal(t, []interface{}{"c5.large", "m5.large"}, requirements[1].(map[string]interface{})["values"])
+}
+
+func TestProvision_MissingComputeConfiguration(t *testing.T) {
+	// Arrange
+	provisioner := provisioners.NewKarpenterProvisioner(newFakeDynamicClient(), provisioners.KarpenterNodeClassRef{})
+
+	// Act
+	err := provisioner.Provision(context.Background(), newWeightedPod("pod1"), nil)
+
+	// Assert
+	assert.Error(t, err, "Expected an error without a compute configuration")
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+)
+
+type ICacheCodec interface {
+	Marshal(value interface{}) ([]byte, error)
+	Unmarshal(data []byte, value interface{}) error
+}
+
+type GobCacheCodec struct{}
+
+type JsonCacheCodec struct{}
+
+type ProtobufCacheCodec struct{}
+
+func (c *GobCacheCodec) Marshal(value interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (c *GobCacheCodec) Unmarshal(data []byte, value interface{}) error {
+	return gob.NewDecoder(bytes.NewBuffer(data)).Decode(value)
+}
+
+func (c *JsonCacheCodec) Marshal(value interface{}) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (c *JsonCacheCodec) Unmarshal(data []byte, value interface{}) error {
+	return json.Unmarshal(data, value)
+}
+
+func (c *ProtobufCacheCodec) Marshal(value interface{}) ([]byte, error) {
+	message, ok := value.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("value of type %T is not a protobuf message", value)
+	}
+
+	return proto.Marshal(message)
+}
+
+func (c *ProtobufCacheCodec) Unmarshal(data []byte, value interface{}) error {
+	if message, ok := value.(proto.Message); ok {
+		return proto.Unmarshal(data, message)
+	}
+
+	target := reflect.ValueOf(value)
+	if target.Kind() == reflect.Pointer && target.Elem().Kind() == reflect.Pointer {
+		target.Elem().Set(reflect.New(target.Elem().Type().Elem()))
+
+		if message, ok := target.Elem().Interface().(proto.Message); ok {
+			return proto.Unmarshal(data, message)
+		}
+	}
+
+	return fmt.Errorf("value of type %T is not a protobuf message", value)
+}
@@ -0,0 +1,49 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+)
+
+func TestCacheCodecs_RoundTrip(t *testing.T) {
+	codecs := map[string]services.ICacheCodec{
+		"gob":  &services.GobCacheCodec{},
+		"json": &services.JsonCacheCodec{},
+	}
+
+	rates := []ultron.WeightedInteruptionRate{
+		{Selector: map[string]string{ultron.LabelInstanceType: "t3.medium"}, Weight: 0.2},
+	}
+
+	for name, codec := range codecs {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			data, err := codec.Marshal(rates)
+			var decoded []ultron.WeightedInteruptionRate
+			decodeErr := codec.Unmarshal(data, &decoded)
+
+			// Assert
+			assert.NoError(t, err, "Marshal should not return an error")
+			assert.NoError(t, decodeErr, "Unmarshal should not return an error")
+			assert.Equal(t, rates, decoded)
+		})
+	}
+}
+
+func TestProtobufCacheCodec_RejectsNonMessages(t *testing.T) {
+	// Arrange
+	codec := &services.ProtobufCacheCodec{}
+
+	// Act
+	_, err := codec.Marshal("ultron")
+	var value string
+	decodeErr := codec.Unmarshal([]byte{}, &value)
+
+	// Assert
+	assert.Error(t, err, "Expected an error when marshalling a non protobuf value")
+	assert.Error(t, decodeErr, "Expected an error when unmarshalling into a non protobuf value")
+}
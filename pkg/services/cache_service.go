@@ -1,100 +1,259 @@
 package services
 
 import (
-	"bytes"
 	"context"
-	"encoding/gob"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	ultron "github.com/be-heroes/ultron/pkg"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+	tracing "github.com/be-heroes/ultron/pkg/tracing"
 	"github.com/patrickmn/go-cache"
 	"github.com/redis/go-redis/v9"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ICacheService interface {
-	AddCacheItem(key string, value interface{}, d time.Duration) error
-	GetCacheItem(key string) (interface{}, error)
-	GetAllComputeConfigurations() ([]ultron.ComputeConfiguration, error)
-	GetEphemeralComputeConfigurations() ([]ultron.ComputeConfiguration, error)
-	GetDurableComputeConfigurations() ([]ultron.ComputeConfiguration, error)
-	GetWeightedNodes() ([]ultron.WeightedNode, error)
-	GetWeightedInteruptionRates() ([]ultron.WeightedInteruptionRate, error)
-	GetWeightedLatencyRates() ([]ultron.WeightedLatencyRate, error)
+	AddCacheItem(ctx context.Context, key string, value interface{}, d time.Duration) error
+	GetCacheItem(ctx context.Context, key string) (interface{}, error)
+	GetCacheItemUpdatedAt(key string) (time.Time, bool)
+	GetAllComputeConfigurations(ctx context.Context) ([]ultron.ComputeConfiguration, error)
+	GetEphemeralComputeConfigurations(ctx context.Context) ([]ultron.ComputeConfiguration, error)
+	GetDurableComputeConfigurations(ctx context.Context) ([]ultron.ComputeConfiguration, error)
+	GetWeightedNodes(ctx context.Context) ([]ultron.WeightedNode, error)
+	GetWeightedInteruptionRates(ctx context.Context) ([]ultron.WeightedInteruptionRate, error)
+	GetWeightedLatencyRates(ctx context.Context) ([]ultron.WeightedLatencyRate, error)
 }
 
 type CacheService struct {
 	memCache    *cache.Cache
 	redisClient *redis.Client
+	redisStore  *RedisCacheStore
+	localTtl    time.Duration
+	instanceId  string
+	mutex       sync.RWMutex
+	updatedAt   map[string]time.Time
+}
+
+type cacheInvalidationMessage struct {
+	InstanceId string `json:"instanceId"`
+	Key        string `json:"key"`
 }
 
-func NewCacheService(innerCache *cache.Cache, redisClient *redis.Client) *CacheService {
+// NewCacheService caps every in-memory item backed by Redis at localTtl when it is positive, as
+// invalidations are best effort and expiry is what bounds how long a missed one leaves this replica stale.
+func NewCacheService(innerCache *cache.Cache, redisClient *redis.Client, localTtl time.Duration) *CacheService {
 	if innerCache == nil && redisClient == nil {
 		innerCache = cache.New(cache.NoExpiration, cache.NoExpiration)
 	}
 
+	var redisStore *RedisCacheStore
+	if redisClient != nil {
+		redisStore = NewRedisCacheStore(redisClient)
+	}
+
 	return &CacheService{
 		memCache:    innerCache,
 		redisClient: redisClient,
+		redisStore:  redisStore,
+		localTtl:    localTtl,
+		instanceId:  newInstanceId(),
+		updatedAt:   make(map[string]time.Time),
 	}
 }
 
-func (c *CacheService) AddCacheItem(key string, value interface{}, d time.Duration) error {
-	if c.memCache != nil {
-		c.memCache.Set(key, value, cache.DefaultExpiration)
-	} else if c.redisClient != nil {
-		var buf bytes.Buffer
-		enc := gob.NewEncoder(&buf)
-		err := enc.Encode(value)
-		if err != nil {
+func (c *CacheService) AddCacheItem(ctx context.Context, key string, value interface{}, d time.Duration) error {
+	ctx, span := tracing.StartSpan(ctx, "CacheService.AddCacheItem", trace.WithAttributes(attribute.String("ultron.cache.key", key)))
+	defer span.End()
+
+	if c.memCache == nil && c.redisStore == nil {
+		return fmt.Errorf("both memCache and redisClient are nil")
+	}
+
+	if c.redisStore != nil {
+		if err := c.redisStore.Set(ctx, key, value, d); err != nil {
 			return err
 		}
+	}
 
-		c.redisClient.Set(context.Background(), key, buf.Bytes(), cache.DefaultExpiration)
-	} else {
-		return fmt.Errorf("both memCache and redisClient are nil")
+	if c.memCache != nil {
+		c.memCache.Set(key, value, c.localExpiration(d))
+	}
+
+	c.touch(key)
+
+	if c.memCache != nil && c.redisStore != nil {
+		return c.publishInvalidation(ctx, key)
 	}
 
 	return nil
 }
 
-func (c *CacheService) GetCacheItem(key string) (interface{}, error) {
-	var data []byte
-	var err error
-	var found bool
-	var returnValue interface{}
+func (c *CacheService) GetCacheItem(ctx context.Context, key string) (interface{}, error) {
+	ctx, span := tracing.StartSpan(ctx, "CacheService.GetCacheItem", trace.WithAttributes(attribute.String("ultron.cache.key", key)))
+	defer span.End()
+
+	if c.memCache == nil && c.redisStore == nil {
+		return nil, fmt.Errorf("both memCache and redisClient are nil")
+	}
 
 	if c.memCache != nil {
-		returnValue, found = c.memCache.Get(key)
-		if !found {
-			return nil, fmt.Errorf("key not found")
+		if returnValue, found := c.memCache.Get(key); found {
+			recordCacheResult(span, key, metrics.CacheResultHit)
+
+			return returnValue, nil
 		}
-	} else if c.redisClient != nil {
-		data, err = c.redisClient.Get(context.Background(), key).Bytes()
-		if err != nil {
-			return nil, err
+
+		if c.redisStore == nil {
+			recordCacheResult(span, key, metrics.CacheResultMiss)
+
+			return nil, ErrCacheKeyNotFound
 		}
+	}
+
+	returnValue, ttl, err := c.redisStore.GetWithTtl(ctx, key)
+	if errors.Is(err, ErrCacheKeyNotFound) {
+		recordCacheResult(span, key, metrics.CacheResultMiss)
+
+		return nil, err
+	}
+
+	if err != nil {
+		recordCacheResult(span, key, metrics.CacheResultError)
+
+		return nil, err
+	}
 
-		buf := bytes.NewBuffer(data)
-		dec := gob.NewDecoder(buf)
-		err = dec.Decode(returnValue)
-		if err != nil {
-			return nil, err
+	recordCacheResult(span, key, metrics.CacheResultHit)
+
+	if c.memCache != nil {
+		if ttl <= 0 {
+			ttl = cache.DefaultExpiration
 		}
-	} else {
-		return nil, fmt.Errorf("both memCache and redisClient are nil")
+
+		c.memCache.Set(key, returnValue, c.localExpiration(ttl))
 	}
 
 	return returnValue, nil
 }
 
-func (c *CacheService) GetAllComputeConfigurations() ([]ultron.ComputeConfiguration, error) {
-	durableConfigurations, err := c.GetDurableComputeConfigurations()
+// GetCacheItemUpdatedAt reports when this instance last wrote the key or was told another instance did.
+func (c *CacheService) GetCacheItemUpdatedAt(key string) (time.Time, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	updatedAt, found := c.updatedAt[key]
+
+	return updatedAt, found
+}
+
+func (c *CacheService) StartInvalidationListener(ctx context.Context) error {
+	if c.memCache == nil || c.redisClient == nil {
+		return nil
+	}
+
+	pubSub := c.redisClient.Subscribe(ctx, ultron.TopicCacheInvalidate)
+	if _, err := pubSub.Receive(ctx); err != nil {
+		pubSub.Close()
+
+		return err
+	}
+
+	go func() {
+		defer pubSub.Close()
+
+		messages := pubSub.Channel()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-messages:
+				if !ok {
+					return
+				}
+
+				var invalidation cacheInvalidationMessage
+				if err := json.Unmarshal([]byte(message.Payload), &invalidation); err != nil {
+					continue
+				}
+
+				if invalidation.InstanceId != c.instanceId {
+					c.memCache.Delete(invalidation.Key)
+					c.touch(invalidation.Key)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (c *CacheService) publishInvalidation(ctx context.Context, key string) error {
+	payload, err := json.Marshal(cacheInvalidationMessage{InstanceId: c.instanceId, Key: key})
+	if err != nil {
+		return err
+	}
+
+	if err := c.redisClient.Publish(ctx, ultron.TopicCacheInvalidate, payload).Err(); err != nil {
+		metrics.RedisPublishFailuresTotal.WithLabelValues(ultron.TopicCacheInvalidate).Inc()
+
+		return fmt.Errorf("failed to publish cache invalidation for key %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (c *CacheService) localExpiration(d time.Duration) time.Duration {
+	if c.localTtl <= 0 || c.redisStore == nil {
+		return d
+	}
+
+	if d <= 0 || d > c.localTtl {
+		return c.localTtl
+	}
+
+	return d
+}
+
+func (c *CacheService) touch(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.updatedAt[key] = time.Now()
+}
+
+func recordCacheResult(span trace.Span, key string, result string) {
+	metrics.CacheRequestsTotal.WithLabelValues(key, result).Inc()
+	span.SetAttributes(attribute.String("ultron.cache.result", result))
+}
+
+func newInstanceId() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(buf)
+}
+
+func (c *CacheService) GetAllComputeConfigurations(ctx context.Context) ([]ultron.ComputeConfiguration, error) {
+	ctx, span := tracing.StartSpan(ctx, "CacheService.GetAllComputeConfigurations")
+	defer span.End()
+
+	durableConfigurations, err := c.GetDurableComputeConfigurations(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	ephemeralConfigurations, err := c.GetEphemeralComputeConfigurations()
+	ephemeralConfigurations, err := c.GetEphemeralComputeConfigurations(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -102,8 +261,11 @@ func (c *CacheService) GetAllComputeConfigurations() ([]ultron.ComputeConfigurat
 	return append(durableConfigurations, ephemeralConfigurations...), nil
 }
 
-func (c *CacheService) GetEphemeralComputeConfigurations() ([]ultron.ComputeConfiguration, error) {
-	ephemeralConfigurationsRaw, err := c.GetCacheItem(ultron.CacheKeyEphemeralComputeConfigurations)
+func (c *CacheService) GetEphemeralComputeConfigurations(ctx context.Context) ([]ultron.ComputeConfiguration, error) {
+	ctx, span := tracing.StartSpan(ctx, "CacheService.GetEphemeralComputeConfigurations")
+	defer span.End()
+
+	ephemeralConfigurationsRaw, err := c.GetCacheItem(ctx, ultron.CacheKeyEphemeralComputeConfigurations)
 	if err != nil {
 		return nil, err
 	}
@@ -111,8 +273,11 @@ func (c *CacheService) GetEphemeralComputeConfigurations() ([]ultron.ComputeConf
 	return ephemeralConfigurationsRaw.([]ultron.ComputeConfiguration), nil
 }
 
-func (c *CacheService) GetDurableComputeConfigurations() ([]ultron.ComputeConfiguration, error) {
-	durableConfigurationsRaw, err := c.GetCacheItem(ultron.CacheKeyDurableComputeConfigurations)
+func (c *CacheService) GetDurableComputeConfigurations(ctx context.Context) ([]ultron.ComputeConfiguration, error) {
+	ctx, span := tracing.StartSpan(ctx, "CacheService.GetDurableComputeConfigurations")
+	defer span.End()
+
+	durableConfigurationsRaw, err := c.GetCacheItem(ctx, ultron.CacheKeyDurableComputeConfigurations)
 	if err != nil {
 		return nil, err
 	}
@@ -120,8 +285,11 @@ func (c *CacheService) GetDurableComputeConfigurations() ([]ultron.ComputeConfig
 	return durableConfigurationsRaw.([]ultron.ComputeConfiguration), nil
 }
 
-func (c *CacheService) GetWeightedNodes() ([]ultron.WeightedNode, error) {
-	weightedNodesInterface, err := c.GetCacheItem(ultron.CacheKeyWeightedNodes)
+func (c *CacheService) GetWeightedNodes(ctx context.Context) ([]ultron.WeightedNode, error) {
+	ctx, span := tracing.StartSpan(ctx, "CacheService.GetWeightedNodes")
+	defer span.End()
+
+	weightedNodesInterface, err := c.GetCacheItem(ctx, ultron.CacheKeyWeightedNodes)
 	if err != nil {
 		return nil, err
 	}
@@ -129,8 +297,11 @@ func (c *CacheService) GetWeightedNodes() ([]ultron.WeightedNode, error) {
 	return weightedNodesInterface.([]ultron.WeightedNode), nil
 }
 
-func (c *CacheService) GetWeightedInteruptionRates() ([]ultron.WeightedInteruptionRate, error) {
-	weightedInteruptionRatesInterface, err := c.GetCacheItem(ultron.CacheKeyEphemeralComputeConfigurationInteruptionRates)
+func (c *CacheService) GetWeightedInteruptionRates(ctx context.Context) ([]ultron.WeightedInteruptionRate, error) {
+	ctx, span := tracing.StartSpan(ctx, "CacheService.GetWeightedInteruptionRates")
+	defer span.End()
+
+	weightedInteruptionRatesInterface, err := c.GetCacheItem(ctx, ultron.CacheKeyEphemeralComputeConfigurationInteruptionRates)
 	if err != nil {
 		return nil, err
 	}
@@ -138,8 +309,11 @@ func (c *CacheService) GetWeightedInteruptionRates() ([]ultron.WeightedInterupti
 	return weightedInteruptionRatesInterface.([]ultron.WeightedInteruptionRate), nil
 }
 
-func (c *CacheService) GetWeightedLatencyRates() ([]ultron.WeightedLatencyRate, error) {
-	weightedLatencyRatesInterface, err := c.GetCacheItem(ultron.CacheKeyDurableComputeConfigurationLatencyRates)
+func (c *CacheService) GetWeightedLatencyRates(ctx context.Context) ([]ultron.WeightedLatencyRate, error) {
+	ctx, span := tracing.StartSpan(ctx, "CacheService.GetWeightedLatencyRates")
+	defer span.End()
+
+	weightedLatencyRatesInterface, err := c.GetCacheItem(ctx, ultron.CacheKeyDurableComputeConfigurationLatencyRates)
 	if err != nil {
 		return nil, err
 	}
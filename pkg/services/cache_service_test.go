@@ -1,11 +1,15 @@
 package services_test
 
 import (
+	"context"
 	"testing"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 
 	ultron "github.com/be-heroes/ultron/pkg"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
 	services "github.com/be-heroes/ultron/pkg/services"
 
 	goCache "github.com/patrickmn/go-cache"
@@ -16,7 +20,7 @@ func TestNewCache(t *testing.T) {
 	var iCache *services.CacheService = nil
 
 	// Act
-	iCache = services.NewCacheService(nil, nil)
+	iCache = services.NewCacheService(nil, nil, 0)
 
 	// Assert
 	assert.NotNil(t, iCache, "NewCacheService should not return nil")
@@ -24,7 +28,7 @@ func TestNewCache(t *testing.T) {
 
 func TestGetEphemeralComputeConfigurations(t *testing.T) {
 	// Arrange
-	iCache := services.NewCacheService(nil, nil)
+	iCache := services.NewCacheService(nil, nil, 0)
 
 	computeConfigs := []ultron.ComputeConfiguration{
 		{Identifier: nil, ComputeType: ultron.ComputeTypeEphemeral, Provider: nil, Location: nil, DataCenter: nil, OsType: nil, OsVersion: nil, CloudNetworkTypes: nil, VCpuType: nil, VCpu: nil, RamGb: nil, VolumeGb: nil, VolumeType: nil, Cost: nil},
@@ -32,9 +36,9 @@ func TestGetEphemeralComputeConfigurations(t *testing.T) {
 	}
 
 	// Act
-	iCache.AddCacheItem(ultron.CacheKeyEphemeralComputeConfigurations, computeConfigs, goCache.DefaultExpiration)
+	iCache.AddCacheItem(context.Background(), ultron.CacheKeyEphemeralComputeConfigurations, computeConfigs, goCache.DefaultExpiration)
 
-	getComputeConfigs, err := iCache.GetEphemeralComputeConfigurations()
+	getComputeConfigs, err := iCache.GetEphemeralComputeConfigurations(context.Background())
 
 	// Assert
 	assert.NoError(t, err, "GetEphemeralComputeConfigurations should not return an error")
@@ -48,10 +52,10 @@ func TestGetEphemeralComputeConfigurations(t *testing.T) {
 
 func TestGetEphemeralComputeConfigurations_NotFound(t *testing.T) {
 	// Arrange
-	iCache := services.NewCacheService(nil, nil)
+	iCache := services.NewCacheService(nil, nil, 0)
 
 	// Act
-	_, err := iCache.GetEphemeralComputeConfigurations()
+	_, err := iCache.GetEphemeralComputeConfigurations(context.Background())
 
 	// Assert
 	assert.Error(t, err, "Expected an error when spot configurations are not found in the cache")
@@ -59,7 +63,7 @@ func TestGetEphemeralComputeConfigurations_NotFound(t *testing.T) {
 
 func TestGetDurableComputeConfigurations(t *testing.T) {
 	// Arrange
-	iCache := services.NewCacheService(nil, nil)
+	iCache := services.NewCacheService(nil, nil, 0)
 
 	computeConfigs := []ultron.ComputeConfiguration{
 		{Identifier: nil, ComputeType: ultron.ComputeTypeDurable, Provider: nil, Location: nil, DataCenter: nil, OsType: nil, OsVersion: nil, CloudNetworkTypes: nil, VCpuType: nil, VCpu: nil, RamGb: nil, VolumeGb: nil, VolumeType: nil, Cost: nil},
@@ -67,9 +71,9 @@ func TestGetDurableComputeConfigurations(t *testing.T) {
 	}
 
 	// Act
-	iCache.AddCacheItem(ultron.CacheKeyDurableComputeConfigurations, computeConfigs, goCache.DefaultExpiration)
+	iCache.AddCacheItem(context.Background(), ultron.CacheKeyDurableComputeConfigurations, computeConfigs, goCache.DefaultExpiration)
 
-	getComputeConfigs, err := iCache.GetDurableComputeConfigurations()
+	getComputeConfigs, err := iCache.GetDurableComputeConfigurations(context.Background())
 
 	// Assert
 	assert.NoError(t, err, "GetDurableComputeConfigurations should not return an error")
@@ -83,11 +87,120 @@ func TestGetDurableComputeConfigurations(t *testing.T) {
 
 func TestGetDurableComputeConfigurations_NotFound(t *testing.T) {
 	// Arrange
-	iCache := services.NewCacheService(nil, nil)
+	iCache := services.NewCacheService(nil, nil, 0)
 
 	// Act
-	_, err := iCache.GetDurableComputeConfigurations()
+	_, err := iCache.GetDurableComputeConfigurations(context.Background())
 
 	// Assert
 	assert.Error(t, err, "Expected an error when durable configurations are not found in the cache")
 }
+
+func TestTwoTierCache_ReadsThroughToRedis(t *testing.T) {
+	// Arrange
+	server, redisClient := newTestRedisClient(t)
+	writer := services.NewCacheService(goCache.New(goCache.NoExpiration, goCache.NoExpiration), redisClient, 0)
+	reader := services.NewCacheService(goCache.New(goCache.NoExpiration, goCache.NoExpiration), redisClient, 0)
+
+	wNodes := []ultron.WeightedNode{{Selector: map[string]string{ultron.LabelHostName: "node1"}}}
+
+	// Act
+	err := writer.AddCacheItem(context.Background(), ultron.CacheKeyWeightedNodes, wNodes, goCache.DefaultExpiration)
+	firstRead, firstErr := reader.GetWeightedNodes(context.Background())
+	server.Close()
+	secondRead, secondErr := reader.GetWeightedNodes(context.Background())
+
+	// Assert
+	assert.NoError(t, err, "AddCacheItem should not return an error")
+	assert.NoError(t, firstErr, "Expected the first read to be served from Redis")
+	assert.Equal(t, wNodes, firstRead)
+	assert.NoError(t, secondErr, "Expected the second read to be served from memory")
+	assert.Equal(t, wNodes, secondRead)
+}
+
+func TestTwoTierCache_CapsLocalTtl(t *testing.T) {
+	// Arrange
+	_, redisClient := newTestRedisClient(t)
+	writerCache := goCache.New(goCache.NoExpiration, goCache.NoExpiration)
+	readerCache := goCache.New(goCache.NoExpiration, goCache.NoExpiration)
+	writer := services.NewCacheService(writerCache, redisClient, time.Minute)
+	reader := services.NewCacheService(readerCache, redisClient, time.Minute)
+
+	// Act
+	err := writer.AddCacheItem(context.Background(), ultron.CacheKeyWeightedNodes, []ultron.WeightedNode{}, time.Hour)
+	_, readErr := reader.GetWeightedNodes(context.Background())
+	_, writerExpiration, _ := writerCache.GetWithExpiration(ultron.CacheKeyWeightedNodes)
+	_, readerExpiration, _ := readerCache.GetWithExpiration(ultron.CacheKeyWeightedNodes)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, readErr)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), writerExpiration, 5*time.Second, "Expected writes to be capped at the local TTL")
+	assert.WithinDuration(t, time.Now().Add(time.Minute), readerExpiration, 5*time.Second, "Expected reads through Redis to be capped at the local TTL")
+}
+
+func TestTwoTierCache_InvalidatesOtherReplicas(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	_, redisClient := newTestRedisClient(t)
+	writer := services.NewCacheService(goCache.New(goCache.NoExpiration, goCache.NoExpiration), redisClient, 0)
+	reader := services.NewCacheService(goCache.New(goCache.NoExpiration, goCache.NoExpiration), redisClient, 0)
+
+	assert.NoError(t, reader.StartInvalidationListener(ctx), "StartInvalidationListener should not return an error")
+
+	_ = writer.AddCacheItem(context.Background(), ultron.CacheKeyWeightedNodes, []ultron.WeightedNode{{Selector: map[string]string{ultron.LabelHostName: "node1"}}}, goCache.DefaultExpiration)
+	_, _ = reader.GetWeightedNodes(context.Background())
+
+	// Act
+	err := writer.AddCacheItem(context.Background(), ultron.CacheKeyWeightedNodes, []ultron.WeightedNode{{Selector: map[string]string{ultron.LabelHostName: "node2"}}}, goCache.DefaultExpiration)
+
+	// Assert
+	assert.NoError(t, err, "AddCacheItem should not return an error")
+	assert.Eventually(t, func() bool {
+		wNodes, err := reader.GetWeightedNodes(context.Background())
+
+		return err == nil && len(wNodes) == 1 && wNodes[0].Selector[ultron.LabelHostName] == "node2"
+	}, 5*time.Second, 10*time.Millisecond, "Expected the reader to drop its stale entry")
+}
+
+func TestGetCacheItem_RecordsHitsAndMisses(t *testing.T) {
+	// Arrange
+	iCache := services.NewCacheService(nil, nil, 0)
+	hits := metrics.CacheRequestsTotal.WithLabelValues(ultron.CacheKeyWeightedNodes, metrics.CacheResultHit)
+	misses := metrics.CacheRequestsTotal.WithLabelValues(ultron.CacheKeyWeightedNodes, metrics.CacheResultMiss)
+	hitsBefore := testutil.ToFloat64(hits)
+	missesBefore := testutil.ToFloat64(misses)
+
+	// Act
+	_, missErr := iCache.GetCacheItem(context.Background(), ultron.CacheKeyWeightedNodes)
+
+	iCache.AddCacheItem(context.Background(), ultron.CacheKeyWeightedNodes, []ultron.WeightedNode{}, goCache.DefaultExpiration)
+
+	_, hitErr := iCache.GetCacheItem(context.Background(), ultron.CacheKeyWeightedNodes)
+
+	// Assert
+	assert.ErrorIs(t, missErr, services.ErrCacheKeyNotFound)
+	assert.NoError(t, hitErr, "GetCacheItem should not return an error")
+	assert.Equal(t, missesBefore+1, testutil.ToFloat64(misses), "Expected a cache miss to be recorded")
+	assert.Equal(t, hitsBefore+1, testutil.ToFloat64(hits), "Expected a cache hit to be recorded")
+}
+
+func TestGetCacheItemUpdatedAt(t *testing.T) {
+	// Arrange
+	iCache := services.NewCacheService(nil, nil, 0)
+	before := time.Now()
+
+	// Act
+	_, foundBefore := iCache.GetCacheItemUpdatedAt(ultron.CacheKeyWeightedNodes)
+
+	iCache.AddCacheItem(context.Background(), ultron.CacheKeyWeightedNodes, []ultron.WeightedNode{}, goCache.DefaultExpiration)
+
+	updatedAt, foundAfter := iCache.GetCacheItemUpdatedAt(ultron.CacheKeyWeightedNodes)
+
+	// Assert
+	assert.False(t, foundBefore, "Expected no update time before the key is written")
+	assert.True(t, foundAfter, "Expected an update time after the key is written")
+	assert.False(t, updatedAt.Before(before), "Expected the update time to be recorded on write")
+}
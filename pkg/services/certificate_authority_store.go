@@ -0,0 +1,50 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+var (
+	ErrCertificateAuthorityNotFound = errors.New("certificate authority not found")
+	ErrCertificateAuthorityConflict = errors.New("certificate authority was modified concurrently")
+)
+
+type ICertificateAuthorityStore interface {
+	Load(ctx context.Context) (*CertificateAuthority, error)
+	Save(ctx context.Context, authority *CertificateAuthority) error
+}
+
+func NewCertificateAuthorityStoreFromConfig(config *ultron.Config, kubernetesConfig *rest.Config) (ICertificateAuthorityStore, error) {
+	switch config.CertificateAuthorityStore {
+	case "":
+		return nil, nil
+	case ultron.CertificateAuthorityStoreFile:
+		if config.CertificateAuthorityLocation == "" {
+			return nil, fmt.Errorf("certificate authority location must be a directory for the file store")
+		}
+
+		return NewFileCertificateAuthorityStore(config.CertificateAuthorityLocation), nil
+	case ultron.CertificateAuthorityStoreSecret:
+		namespace, name, found := strings.Cut(config.CertificateAuthorityLocation, "/")
+		if !found || namespace == "" || name == "" {
+			return nil, fmt.Errorf("secret location must be in the format namespace/name: %s", config.CertificateAuthorityLocation)
+		}
+
+		clientSet, err := kubernetes.NewForConfig(kubernetesConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSecretCertificateAuthorityStore(clientSet, namespace, name), nil
+	default:
+		return nil, fmt.Errorf("unsupported certificate authority store: %s", config.CertificateAuthorityStore)
+	}
+}
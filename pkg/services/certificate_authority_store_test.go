@@ -0,0 +1,100 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestFileCertificateAuthorityStore_LoadMissing(t *testing.T) {
+	// Arrange
+	store := services.NewFileCertificateAuthorityStore(t.TempDir())
+
+	// Act
+	_, err := store.Load(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, err, services.ErrCertificateAuthorityNotFound)
+}
+
+func TestFileCertificateAuthorityStore_SaveAndLoad(t *testing.T) {
+	// Arrange
+	store := services.NewFileCertificateAuthorityStore(t.TempDir())
+	authority, _ := services.NewCertificateService().GenerateCertificateAuthority("TestOrg", "test-ca", ultron.KeyAlgorithmRsa, time.Hour)
+
+	// Act
+	err := store.Save(context.Background(), authority)
+	loaded, loadErr := store.Load(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, loadErr)
+	assert.Equal(t, authority.Certificate.Raw, loaded.Certificate.Raw)
+}
+
+func TestSecretCertificateAuthorityStore_SaveAndLoad(t *testing.T) {
+	// Arrange
+	clientSet := fake.NewSimpleClientset()
+	store := services.NewSecretCertificateAuthorityStore(clientSet, "ultron", "ultron-ca")
+	authority, _ := services.NewCertificateService().GenerateCertificateAuthority("TestOrg", "test-ca", ultron.KeyAlgorithmEcdsa, time.Hour)
+
+	// Act
+	_, missingErr := store.Load(context.Background())
+	err := store.Save(context.Background(), authority)
+	loaded, loadErr := store.Load(context.Background())
+
+	// Assert
+	assert.ErrorIs(t, missingErr, services.ErrCertificateAuthorityNotFound)
+	assert.NoError(t, err)
+	assert.NoError(t, loadErr)
+	assert.Equal(t, authority.Certificate.Raw, loaded.Certificate.Raw)
+
+	secret, _ := clientSet.CoreV1().Secrets("ultron").Get(context.Background(), "ultron-ca", metav1.GetOptions{})
+	assert.Equal(t, corev1.SecretTypeTLS, secret.Type)
+}
+
+func TestSecretCertificateAuthorityStore_SaveConflict(t *testing.T) {
+	// Arrange
+	clientSet := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ultron-ca", Namespace: "ultron"},
+		Type:       corev1.SecretTypeTLS,
+	})
+
+	store := services.NewSecretCertificateAuthorityStore(clientSet, "ultron", "ultron-ca")
+	authority, _ := services.NewCertificateService().GenerateCertificateAuthority("TestOrg", "test-ca", ultron.KeyAlgorithmEcdsa, time.Hour)
+
+	// Act
+	err := store.Save(context.Background(), authority)
+
+	// Assert
+	assert.ErrorIs(t, err, services.ErrCertificateAuthorityConflict, "Expected a conflict when the secret was created by another replica")
+}
+
+func TestNewCertificateAuthorityStoreFromConfig(t *testing.T) {
+	// Arrange
+	fileConfig := &ultron.Config{CertificateAuthorityStore: ultron.CertificateAuthorityStoreFile, CertificateAuthorityLocation: t.TempDir()}
+	invalidSecretConfig := &ultron.Config{CertificateAuthorityStore: ultron.CertificateAuthorityStoreSecret, CertificateAuthorityLocation: "ultron-ca"}
+	unsupportedConfig := &ultron.Config{CertificateAuthorityStore: "vault"}
+
+	// Act
+	noStore, noStoreErr := services.NewCertificateAuthorityStoreFromConfig(&ultron.Config{}, nil)
+	fileStore, fileErr := services.NewCertificateAuthorityStoreFromConfig(fileConfig, nil)
+	_, invalidSecretErr := services.NewCertificateAuthorityStoreFromConfig(invalidSecretConfig, nil)
+	_, unsupportedErr := services.NewCertificateAuthorityStoreFromConfig(unsupportedConfig, nil)
+
+	// Assert
+	assert.NoError(t, noStoreErr)
+	assert.Nil(t, noStore, "Expected no store when none is configured")
+	assert.NoError(t, fileErr)
+	assert.IsType(t, &services.FileCertificateAuthorityStore{}, fileStore)
+	assert.Error(t, invalidSecretErr, "Expected an error for a secret location without a namespace")
+	assert.Error(t, unsupportedErr, "Expected an error for an unsupported store")
+}
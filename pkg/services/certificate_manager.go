@@ -0,0 +1,246 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+)
+
+const certificateRetryInterval = time.Minute
+
+type ICertificateManager interface {
+	ICertificateSource
+	CertificateAuthority() (*CertificateAuthority, error)
+}
+
+type CertificateManager struct {
+	certificateHolder
+	certificateService ICertificateService
+	authorityStore     ICertificateAuthorityStore
+	organization       string
+	commonName         string
+	dnsNames           []string
+	ipAddresses        []net.IP
+	exportPath         string
+	keyAlgorithm       ultron.KeyAlgorithm
+	validity           time.Duration
+	authorityValidity  time.Duration
+	renewBefore        time.Duration
+	authorityMutex     sync.RWMutex
+	authority          *CertificateAuthority
+	previousAuthority  *CertificateAuthority
+}
+
+func NewCertificateManager(certificateService ICertificateService, authorityStore ICertificateAuthorityStore, config *ultron.Config) *CertificateManager {
+	if config == nil {
+		config = &ultron.Config{}
+	}
+
+	var dnsNames []string
+	for _, dnsName := range strings.Split(config.CertificateDnsNamesCSV, ",") {
+		if dnsName != "" {
+			dnsNames = append(dnsNames, dnsName)
+		}
+	}
+
+	validity := config.CertificateValidity
+	if validity <= 0 {
+		validity = 7 * 24 * time.Hour
+	}
+
+	authorityValidity := config.CertificateAuthorityValidity
+	if authorityValidity <= 0 {
+		authorityValidity = 10 * 365 * 24 * time.Hour
+	}
+
+	return &CertificateManager{
+		certificateService: certificateService,
+		authorityStore:     authorityStore,
+		organization:       config.CertificateOrganization,
+		commonName:         config.CertificateCommonName,
+		dnsNames:           dnsNames,
+		ipAddresses:        ultron.ParseCsvIpAddressString(config.CertificateIpAddressesCSV),
+		exportPath:         config.CertificateExportPath,
+		keyAlgorithm:       config.CertificateKeyAlgorithm,
+		validity:           validity,
+		authorityValidity:  authorityValidity,
+		renewBefore:        config.CertificateRenewBefore,
+	}
+}
+
+func (m *CertificateManager) CertificateAuthority() (*CertificateAuthority, error) {
+	m.authorityMutex.RLock()
+	defer m.authorityMutex.RUnlock()
+
+	if m.authority == nil {
+		return nil, fmt.Errorf("no certificate authority has been loaded yet")
+	}
+
+	return m.authority, nil
+}
+
+func (m *CertificateManager) Rotate(ctx context.Context) error {
+	authority, err := m.ensureCertificateAuthority(ctx)
+	if err != nil {
+		metrics.CertificateRotationsTotal.WithLabelValues(metrics.RotationResultError).Inc()
+
+		return err
+	}
+
+	m.authorityMutex.Lock()
+	replaced := m.authority != nil && !bytes.Equal(m.authority.Certificate.Raw, authority.Certificate.Raw)
+	if replaced {
+		m.previousAuthority = m.authority
+	}
+
+	caBundle := m.caBundle(authority)
+	m.authorityMutex.Unlock()
+
+	// Clients must trust a replacement authority before anything it issued is served, so the old
+	// certificate keeps serving until the overlapping bundle is published.
+	if replaced {
+		if err := m.publish(ctx, caBundle); err != nil {
+			metrics.CertificateRotationsTotal.WithLabelValues(metrics.RotationResultError).Inc()
+
+			return fmt.Errorf("failed to publish CA bundle: %w", err)
+		}
+	}
+
+	certificate, err := m.certificateService.IssueServingCert(authority, m.organization, m.commonName, m.dnsNames, m.ipAddresses, m.keyAlgorithm, m.validity)
+	if err != nil {
+		metrics.CertificateRotationsTotal.WithLabelValues(metrics.RotationResultError).Inc()
+
+		return fmt.Errorf("failed to issue certificate: %w", err)
+	}
+
+	m.authorityMutex.Lock()
+	m.authority = authority
+	m.authorityMutex.Unlock()
+
+	if err := m.swap(ctx, &certificate, caBundle); err != nil {
+		return err
+	}
+
+	if m.exportPath != "" {
+		if err := m.certificateService.ExportCACert(authority.Certificate.Raw, m.exportPath); err != nil {
+			return fmt.Errorf("failed to export CA certificate: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RenewAt is renewBefore ahead of expiry, or two thirds into the lifetime when renewBefore does not fit it.
+func (m *CertificateManager) RenewAt() time.Time {
+	certificate, err := m.Certificate()
+	if err != nil {
+		return time.Now()
+	}
+
+	lifetime := certificate.Leaf.NotAfter.Sub(certificate.Leaf.NotBefore)
+	if m.renewBefore <= 0 || m.renewBefore >= lifetime {
+		return certificate.Leaf.NotBefore.Add(lifetime * 2 / 3)
+	}
+
+	return certificate.Leaf.NotAfter.Add(-m.renewBefore)
+}
+
+func (m *CertificateManager) Start(ctx context.Context) error {
+	var err error
+
+	for {
+		wait := time.Until(m.RenewAt())
+		if wait < certificateRetryInterval || err != nil {
+			wait = certificateRetryInterval
+		}
+
+		timer := time.NewTimer(wait)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		if err = m.Rotate(ctx); err != nil {
+			log.Printf("Could not rotate certificate: %v", err)
+		}
+	}
+}
+
+func (m *CertificateManager) ensureCertificateAuthority(ctx context.Context) (*CertificateAuthority, error) {
+	m.authorityMutex.RLock()
+	authority := m.authority
+	m.authorityMutex.RUnlock()
+
+	if m.canIssue(authority) {
+		return authority, nil
+	}
+
+	if m.authorityStore != nil {
+		authority, err := m.authorityStore.Load(ctx)
+		if err != nil && !errors.Is(err, ErrCertificateAuthorityNotFound) {
+			return nil, fmt.Errorf("failed to load certificate authority: %w", err)
+		}
+
+		if m.canIssue(authority) {
+			return authority, nil
+		}
+	}
+
+	authority, err := m.certificateService.GenerateCertificateAuthority(m.organization, ultron.CertificateAuthorityCommonName, m.keyAlgorithm, m.authorityValidity)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificate authority: %w", err)
+	}
+
+	if m.authorityStore == nil {
+		return authority, nil
+	}
+
+	err = m.authorityStore.Save(ctx, authority)
+	if errors.Is(err, ErrCertificateAuthorityConflict) {
+		// Another replica persisted a CA first; issuing from it keeps a single trust root.
+		authority, err = m.authorityStore.Load(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load certificate authority: %w", err)
+		}
+
+		return authority, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to save certificate authority: %w", err)
+	}
+
+	return authority, nil
+}
+
+// caBundle trusts the previous authority next to the current one until it expires, as certificates
+// it issued may still be served by this or other replicas.
+func (m *CertificateManager) caBundle(authority *CertificateAuthority) []byte {
+	if m.previousAuthority != nil && time.Now().After(m.previousAuthority.Certificate.NotAfter) {
+		m.previousAuthority = nil
+	}
+
+	if m.previousAuthority == nil {
+		return authority.CertificatePem()
+	}
+
+	return append(authority.CertificatePem(), m.previousAuthority.CertificatePem()...)
+}
+
+// canIssue reports whether the authority outlives a full serving certificate.
+func (m *CertificateManager) canIssue(authority *CertificateAuthority) bool {
+	return authority != nil && time.Until(authority.Certificate.NotAfter) > m.validity
+}
@@ -0,0 +1,338 @@
+package services_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/be-heroes/ultron/mocks"
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newCertificateManagerConfig(exportPath string) *ultron.Config {
+	return &ultron.Config{
+		CertificateOrganization:      "TestOrg",
+		CertificateCommonName:        "test.com",
+		CertificateDnsNamesCSV:       "test.com,www.test.com",
+		CertificateIpAddressesCSV:    "127.0.0.1",
+		CertificateExportPath:        exportPath,
+		CertificateRenewBefore:       48 * time.Hour,
+		CertificateValidity:          7 * 24 * time.Hour,
+		CertificateKeyAlgorithm:      ultron.KeyAlgorithmEcdsa,
+		CertificateAuthorityValidity: 365 * 24 * time.Hour,
+	}
+}
+
+func TestCertificateManager_GetCertificateBeforeRotate(t *testing.T) {
+	// Arrange
+	manager := services.NewCertificateManager(services.NewCertificateService(), nil, newCertificateManagerConfig(""))
+
+	// Act
+	certificate, err := manager.GetCertificate(&tls.ClientHelloInfo{})
+
+	// Assert
+	assert.Error(t, err, "Expected an error before any certificate is issued")
+	assert.Nil(t, certificate)
+}
+
+func TestCertificateManager_Rotate(t *testing.T) {
+	// Arrange
+	exportPath := filepath.Join(t.TempDir(), "ca.pem")
+	manager := services.NewCertificateManager(services.NewCertificateService(), nil, newCertificateManagerConfig(exportPath))
+
+	// Act
+	err := manager.Rotate(context.Background())
+	first, _ := manager.GetCertificate(&tls.ClientHelloInfo{})
+	rotateErr := manager.Rotate(context.Background())
+	second, _ := manager.GetCertificate(&tls.ClientHelloInfo{})
+	authority, authorityErr := manager.CertificateAuthority()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, rotateErr)
+	assert.NoError(t, authorityErr)
+	assert.ElementsMatch(t, []string{"test.com", "www.test.com"}, first.Leaf.DNSNames)
+	assert.NotEqual(t, first.Leaf.SerialNumber, second.Leaf.SerialNumber, "Expected a new certificate after rotation")
+	assert.NoError(t, second.Leaf.CheckSignatureFrom(authority.Certificate), "Expected the certificate to be issued by the CA")
+
+	exported, err := os.ReadFile(exportPath)
+	assert.NoError(t, err)
+
+	block, _ := pem.Decode(exported)
+	assert.NotNil(t, block)
+
+	exportedCert, err := x509.ParseCertificate(block.Bytes)
+	assert.NoError(t, err)
+	assert.True(t, exportedCert.IsCA, "Expected the CA rather than the serving certificate to be exported")
+	assert.Equal(t, authority.Certificate.SerialNumber, exportedCert.SerialNumber)
+}
+
+func TestCertificateManager_RotateReusesStoredAuthority(t *testing.T) {
+	// Arrange
+	certificateService := services.NewCertificateService()
+	store := services.NewFileCertificateAuthorityStore(t.TempDir())
+	stored, _ := certificateService.GenerateCertificateAuthority("TestOrg", ultron.CertificateAuthorityCommonName, ultron.KeyAlgorithmEcdsa, 365*24*time.Hour)
+	_ = store.Save(context.Background(), stored)
+
+	manager := services.NewCertificateManager(certificateService, store, newCertificateManagerConfig(""))
+
+	// Act
+	err := manager.Rotate(context.Background())
+	authority, _ := manager.CertificateAuthority()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, stored.Certificate.Raw, authority.Certificate.Raw, "Expected the stored CA to survive a restart")
+}
+
+func TestCertificateManager_RotatePersistsNewAuthority(t *testing.T) {
+	// Arrange
+	store := services.NewFileCertificateAuthorityStore(t.TempDir())
+	manager := services.NewCertificateManager(services.NewCertificateService(), store, newCertificateManagerConfig(""))
+
+	// Act
+	err := manager.Rotate(context.Background())
+	authority, _ := manager.CertificateAuthority()
+	stored, loadErr := store.Load(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, loadErr)
+	assert.Equal(t, authority.Certificate.Raw, stored.Certificate.Raw)
+}
+
+func TestCertificateManager_RotateAdoptsAuthorityOnConflict(t *testing.T) {
+	// Arrange
+	certificateService := services.NewCertificateService()
+	winner, _ := certificateService.GenerateCertificateAuthority("TestOrg", ultron.CertificateAuthorityCommonName, ultron.KeyAlgorithmEcdsa, 365*24*time.Hour)
+
+	mockStore := new(mocks.ICertificateAuthorityStore)
+	mockStore.On("Load", mock.Anything).Return(nil, services.ErrCertificateAuthorityNotFound).Once()
+	mockStore.On("Save", mock.Anything, mock.Anything).Return(services.ErrCertificateAuthorityConflict)
+	mockStore.On("Load", mock.Anything).Return(winner, nil).Once()
+
+	manager := services.NewCertificateManager(certificateService, mockStore, newCertificateManagerConfig(""))
+
+	// Act
+	err := manager.Rotate(context.Background())
+	authority, _ := manager.CertificateAuthority()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, winner.Certificate.Raw, authority.Certificate.Raw, "Expected the CA persisted by another replica to be adopted")
+	mockStore.AssertExpectations(t)
+}
+
+func TestCertificateManager_RotateFailureKeepsCurrentCertificate(t *testing.T) {
+	// Arrange
+	certificateService := services.NewCertificateService()
+	authority, _ := certificateService.GenerateCertificateAuthority("TestOrg", ultron.CertificateAuthorityCommonName, ultron.KeyAlgorithmEcdsa, 365*24*time.Hour)
+	certificate, _ := certificateService.IssueServingCert(authority, "TestOrg", "test.com", nil, nil, ultron.KeyAlgorithmEcdsa, time.Hour)
+
+	mockCertificateService := new(mocks.ICertificateService)
+	mockCertificateService.On("GenerateCertificateAuthority", "TestOrg", ultron.CertificateAuthorityCommonName, ultron.KeyAlgorithmEcdsa, mock.Anything).Return(authority, nil)
+	mockCertificateService.On("IssueServingCert", authority, "TestOrg", "test.com", mock.Anything, mock.Anything, ultron.KeyAlgorithmEcdsa, mock.Anything).Return(certificate, nil).Once()
+	mockCertificateService.On("IssueServingCert", authority, "TestOrg", "test.com", mock.Anything, mock.Anything, ultron.KeyAlgorithmEcdsa, mock.Anything).Return(tls.Certificate{}, errors.New("entropy exhausted")).Once()
+
+	manager := services.NewCertificateManager(mockCertificateService, nil, newCertificateManagerConfig(""))
+
+	// Act
+	_ = manager.Rotate(context.Background())
+	err := manager.Rotate(context.Background())
+	current, currentErr := manager.Certificate()
+
+	// Assert
+	assert.Error(t, err)
+	assert.NoError(t, currentErr)
+	assert.Equal(t, certificate.Certificate[0], current.Certificate[0], "Expected the previous certificate to keep serving")
+}
+
+func TestCertificateManager_RenewAt(t *testing.T) {
+	// Arrange
+	manager := services.NewCertificateManager(services.NewCertificateService(), nil, newCertificateManagerConfig(""))
+	_ = manager.Rotate(context.Background())
+	certificate, _ := manager.Certificate()
+
+	// Act
+	renewAt := manager.RenewAt()
+
+	// Assert
+	assert.Equal(t, certificate.Leaf.NotAfter.Add(-48*time.Hour), renewAt)
+}
+
+func TestCertificateManager_RenewAtWhenRenewBeforeExceedsLifetime(t *testing.T) {
+	// Arrange
+	config := newCertificateManagerConfig("")
+	config.CertificateRenewBefore = 30 * 24 * time.Hour
+
+	manager := services.NewCertificateManager(services.NewCertificateService(), nil, config)
+	_ = manager.Rotate(context.Background())
+	certificate, _ := manager.Certificate()
+
+	// Act
+	renewAt := manager.RenewAt()
+
+	// Assert
+	assert.True(t, renewAt.After(certificate.Leaf.NotBefore), "Expected renewal after issuance")
+	assert.True(t, renewAt.Before(certificate.Leaf.NotAfter), "Expected renewal before expiry")
+}
+
+func TestCertificateManager_RotateRunsRotationHandlers(t *testing.T) {
+	// Arrange
+	manager := services.NewCertificateManager(services.NewCertificateService(), nil, newCertificateManagerConfig(""))
+
+	var caBundles [][]byte
+	manager.AddRotationHandler(func(ctx context.Context, caBundle []byte) error {
+		caBundles = append(caBundles, caBundle)
+
+		return nil
+	})
+
+	// Act
+	err := manager.Rotate(context.Background())
+	rotateErr := manager.Rotate(context.Background())
+	authority, _ := manager.CertificateAuthority()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, rotateErr)
+	assert.Len(t, caBundles, 1, "Expected the handler to run only when the CA bundle changes")
+	assert.Equal(t, authority.CertificatePem(), caBundles[0])
+}
+
+func TestCertificateManager_RotateReturnsRotationHandlerError(t *testing.T) {
+	// Arrange
+	manager := services.NewCertificateManager(services.NewCertificateService(), nil, newCertificateManagerConfig(""))
+	manager.AddRotationHandler(func(ctx context.Context, caBundle []byte) error {
+		return errors.New("forbidden")
+	})
+
+	// Act
+	err := manager.Rotate(context.Background())
+	_, certificateErr := manager.Certificate()
+
+	// Assert
+	assert.Error(t, err, "Expected the handler error to surface")
+	assert.NoError(t, certificateErr, "Expected the new certificate to be served regardless")
+}
+
+func TestCertificateManager_RotateRetriesFailedRotationHandler(t *testing.T) {
+	// Arrange
+	manager := services.NewCertificateManager(services.NewCertificateService(), nil, newCertificateManagerConfig(""))
+
+	calls := 0
+	manager.AddRotationHandler(func(ctx context.Context, caBundle []byte) error {
+		calls++
+
+		if calls == 1 {
+			return errors.New("forbidden")
+		}
+
+		return nil
+	})
+
+	// Act
+	err := manager.Rotate(context.Background())
+	retryErr := manager.Rotate(context.Background())
+
+	// Assert
+	assert.Error(t, err)
+	assert.NoError(t, retryErr)
+	assert.Equal(t, 2, calls, "Expected an unchanged CA bundle to be published again after a failure")
+}
+
+func TestCertificateManager_RotateReplacingAuthorityPublishesOverlap(t *testing.T) {
+	// Arrange
+	certificateService := services.NewCertificateService()
+	signer, _ := certificateService.GenerateCertificateAuthority("TestOrg", ultron.CertificateAuthorityCommonName, ultron.KeyAlgorithmEcdsa, 365*24*time.Hour)
+	oldCertificate, _ := certificateService.IssueServingCert(signer, "TestOrg", "test.com", nil, nil, ultron.KeyAlgorithmEcdsa, time.Hour)
+	newCertificate, _ := certificateService.IssueServingCert(signer, "TestOrg", "test.com", nil, nil, ultron.KeyAlgorithmEcdsa, time.Hour)
+	oldAuthority := &services.CertificateAuthority{Certificate: &x509.Certificate{Raw: []byte("old"), NotAfter: time.Now().Add(7*24*time.Hour + 100*time.Millisecond)}}
+	newAuthority := &services.CertificateAuthority{Certificate: &x509.Certificate{Raw: []byte("new"), NotAfter: time.Now().Add(365 * 24 * time.Hour)}}
+
+	mockCertificateService := new(mocks.ICertificateService)
+	mockCertificateService.On("GenerateCertificateAuthority", "TestOrg", ultron.CertificateAuthorityCommonName, ultron.KeyAlgorithmEcdsa, mock.Anything).Return(oldAuthority, nil).Once()
+	mockCertificateService.On("GenerateCertificateAuthority", "TestOrg", ultron.CertificateAuthorityCommonName, ultron.KeyAlgorithmEcdsa, mock.Anything).Return(newAuthority, nil).Once()
+	mockCertificateService.On("IssueServingCert", oldAuthority, "TestOrg", "test.com", mock.Anything, mock.Anything, ultron.KeyAlgorithmEcdsa, mock.Anything).Return(oldCertificate, nil)
+	mockCertificateService.On("IssueServingCert", newAuthority, "TestOrg", "test.com", mock.Anything, mock.Anything, ultron.KeyAlgorithmEcdsa, mock.Anything).Return(newCertificate, nil)
+
+	manager := services.NewCertificateManager(mockCertificateService, nil, newCertificateManagerConfig(""))
+
+	var caBundles [][]byte
+	var servedDuringPublish []byte
+	manager.AddRotationHandler(func(ctx context.Context, caBundle []byte) error {
+		caBundles = append(caBundles, caBundle)
+
+		if served, err := manager.Certificate(); err == nil {
+			servedDuringPublish = served.Certificate[0]
+		}
+
+		return nil
+	})
+
+	_ = manager.Rotate(context.Background())
+
+	time.Sleep(200 * time.Millisecond)
+
+	// Act
+	err := manager.Rotate(context.Background())
+	served, _ := manager.Certificate()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, caBundles, 2)
+	assert.Equal(t, append(newAuthority.CertificatePem(), oldAuthority.CertificatePem()...), caBundles[1], "Expected the bundle to trust both authorities during the overlap")
+	assert.Equal(t, oldCertificate.Certificate[0], servedDuringPublish, "Expected the old certificate to serve until the bundle is published")
+	assert.Equal(t, newCertificate.Certificate[0], served.Certificate[0])
+}
+
+func TestCertificateManager_RotateReplacingAuthorityWaitsForBundle(t *testing.T) {
+	// Arrange
+	certificateService := services.NewCertificateService()
+	signer, _ := certificateService.GenerateCertificateAuthority("TestOrg", ultron.CertificateAuthorityCommonName, ultron.KeyAlgorithmEcdsa, 365*24*time.Hour)
+	oldCertificate, _ := certificateService.IssueServingCert(signer, "TestOrg", "test.com", nil, nil, ultron.KeyAlgorithmEcdsa, time.Hour)
+	oldAuthority := &services.CertificateAuthority{Certificate: &x509.Certificate{Raw: []byte("old"), NotAfter: time.Now().Add(7*24*time.Hour + 100*time.Millisecond)}}
+	newAuthority := &services.CertificateAuthority{Certificate: &x509.Certificate{Raw: []byte("new"), NotAfter: time.Now().Add(365 * 24 * time.Hour)}}
+
+	mockCertificateService := new(mocks.ICertificateService)
+	mockCertificateService.On("GenerateCertificateAuthority", "TestOrg", ultron.CertificateAuthorityCommonName, ultron.KeyAlgorithmEcdsa, mock.Anything).Return(oldAuthority, nil).Once()
+	mockCertificateService.On("GenerateCertificateAuthority", "TestOrg", ultron.CertificateAuthorityCommonName, ultron.KeyAlgorithmEcdsa, mock.Anything).Return(newAuthority, nil).Once()
+	mockCertificateService.On("IssueServingCert", oldAuthority, "TestOrg", "test.com", mock.Anything, mock.Anything, ultron.KeyAlgorithmEcdsa, mock.Anything).Return(oldCertificate, nil)
+
+	manager := services.NewCertificateManager(mockCertificateService, nil, newCertificateManagerConfig(""))
+
+	calls := 0
+	manager.AddRotationHandler(func(ctx context.Context, caBundle []byte) error {
+		calls++
+
+		if calls > 1 {
+			return errors.New("forbidden")
+		}
+
+		return nil
+	})
+
+	_ = manager.Rotate(context.Background())
+
+	time.Sleep(200 * time.Millisecond)
+
+	// Act
+	err := manager.Rotate(context.Background())
+	served, _ := manager.Certificate()
+	authority, _ := manager.CertificateAuthority()
+
+	// Assert
+	assert.Error(t, err, "Expected the handler error to surface")
+	assert.Equal(t, oldCertificate.Certificate[0], served.Certificate[0], "Expected the old certificate to keep serving")
+	assert.Same(t, oldAuthority, authority)
+	mockCertificateService.AssertNotCalled(t, "IssueServingCert", newAuthority, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/tls"
@@ -18,9 +21,20 @@ import (
 
 type ICertificateService interface {
 	GenerateSelfSignedCert(organization string, commonName string, dnsNames []string, ipAddresses []net.IP) (tls.Certificate, error)
+	GenerateCertificateAuthority(organization string, commonName string, keyAlgorithm ultron.KeyAlgorithm, validity time.Duration) (*CertificateAuthority, error)
+	IssueServingCert(authority *CertificateAuthority, organization string, commonName string, dnsNames []string, ipAddresses []net.IP, keyAlgorithm ultron.KeyAlgorithm, validity time.Duration) (tls.Certificate, error)
 	ExportCACert(caCert []byte, filePath string) error
 }
 
+type CertificateAuthority struct {
+	Certificate *x509.Certificate
+	PrivateKey  crypto.Signer
+}
+
+func (a *CertificateAuthority) CertificatePem() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: ultron.BlockTypeCertificate, Bytes: a.Certificate.Raw})
+}
+
 type CertificateService struct {
 }
 
@@ -75,6 +89,114 @@ func (cs *CertificateService) GenerateSelfSignedCert(organization string, common
 	return tlsCert, nil
 }
 
+func (cs *CertificateService) GenerateCertificateAuthority(organization string, commonName string, keyAlgorithm ultron.KeyAlgorithm, validity time.Duration) (*CertificateAuthority, error) {
+	if organization == "" || commonName == "" {
+		return nil, fmt.Errorf("organization and common name must be provided")
+	}
+
+	priv, err := generatePrivateKey(keyAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	certTemplate := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{organization},
+			CommonName:   commonName,
+		},
+		NotBefore: now.Add(-time.Minute),
+		NotAfter:  now.Add(validity),
+
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+
+	certDERBytes, err := x509.CreateCertificate(rand.Reader, &certTemplate, &certTemplate, priv.Public(), priv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CA certificate: %w", err)
+	}
+
+	certificate, err := x509.ParseCertificate(certDERBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	return &CertificateAuthority{Certificate: certificate, PrivateKey: priv}, nil
+}
+
+func (cs *CertificateService) IssueServingCert(authority *CertificateAuthority, organization string, commonName string, dnsNames []string, ipAddresses []net.IP, keyAlgorithm ultron.KeyAlgorithm, validity time.Duration) (tls.Certificate, error) {
+	if authority == nil || authority.Certificate == nil || authority.PrivateKey == nil {
+		return tls.Certificate{}, fmt.Errorf("certificate authority must be provided")
+	}
+
+	if organization == "" || commonName == "" {
+		return tls.Certificate{}, fmt.Errorf("organization and common name must be provided")
+	}
+
+	priv, err := generatePrivateKey(keyAlgorithm)
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	serialNumber, err := newSerialNumber()
+	if err != nil {
+		return tls.Certificate{}, err
+	}
+
+	// Backdating absorbs clock skew between Ultron and the API server, and the CA caps the lifetime.
+	now := time.Now()
+	notAfter := now.Add(validity)
+	if notAfter.After(authority.Certificate.NotAfter) {
+		notAfter = authority.Certificate.NotAfter
+	}
+
+	keyUsage := x509.KeyUsageDigitalSignature
+	if keyAlgorithm != ultron.KeyAlgorithmEcdsa {
+		keyUsage |= x509.KeyUsageKeyEncipherment
+	}
+
+	certTemplate := x509.Certificate{
+		SerialNumber: serialNumber,
+		Subject: pkix.Name{
+			Organization: []string{organization},
+			CommonName:   commonName,
+		},
+		NotBefore: now.Add(-time.Minute),
+		NotAfter:  notAfter,
+
+		KeyUsage:              keyUsage,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              dnsNames,
+		IPAddresses:           ipAddresses,
+	}
+
+	certDERBytes, err := x509.CreateCertificate(rand.Reader, &certTemplate, authority.Certificate, priv.Public(), authority.PrivateKey)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to issue serving certificate: %w", err)
+	}
+
+	leaf, err := x509.ParseCertificate(certDERBytes)
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("failed to parse serving certificate: %w", err)
+	}
+
+	return tls.Certificate{
+		Certificate: [][]byte{certDERBytes},
+		PrivateKey:  priv,
+		Leaf:        leaf,
+	}, nil
+}
+
 func (cs *CertificateService) ExportCACert(caCert []byte, filePath string) error {
 	if caCert == nil {
 		return fmt.Errorf("CA certificate is nil")
@@ -96,3 +218,71 @@ func (cs *CertificateService) ExportCACert(caCert []byte, filePath string) error
 
 	return nil
 }
+
+func EncodeCertificateAuthority(authority *CertificateAuthority) ([]byte, []byte, error) {
+	keyDERBytes, err := x509.MarshalPKCS8PrivateKey(authority.PrivateKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal CA private key: %w", err)
+	}
+
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: ultron.BlockTypePrivateKey, Bytes: keyDERBytes})
+
+	return authority.CertificatePem(), keyPEM, nil
+}
+
+func DecodeCertificateAuthority(certPEM []byte, keyPEM []byte) (*CertificateAuthority, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil || certBlock.Type != ultron.BlockTypeCertificate {
+		return nil, fmt.Errorf("failed to decode CA certificate PEM")
+	}
+
+	certificate, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA certificate: %w", err)
+	}
+
+	if !certificate.IsCA {
+		return nil, fmt.Errorf("certificate %s is not a CA", certificate.Subject.CommonName)
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, fmt.Errorf("failed to decode CA private key PEM")
+	}
+
+	var key any
+	switch keyBlock.Type {
+	case ultron.BlockTypeRsaPrivateKey:
+		key, err = x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	case ultron.BlockTypeEcPrivateKey:
+		key, err = x509.ParseECPrivateKey(keyBlock.Bytes)
+	default:
+		key, err = x509.ParsePKCS8PrivateKey(keyBlock.Bytes)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse CA private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported CA private key type %T", key)
+	}
+
+	return &CertificateAuthority{Certificate: certificate, PrivateKey: signer}, nil
+}
+
+func generatePrivateKey(keyAlgorithm ultron.KeyAlgorithm) (crypto.Signer, error) {
+	switch keyAlgorithm {
+	case ultron.KeyAlgorithmEcdsa:
+		return ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case ultron.KeyAlgorithmRsa, "":
+		return rsa.GenerateKey(rand.Reader, 2048)
+	default:
+		return nil, fmt.Errorf("unsupported key algorithm: %s", keyAlgorithm)
+	}
+}
+
+func newSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
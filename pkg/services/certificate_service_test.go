@@ -1,6 +1,7 @@
 package services_test
 
 import (
+	"crypto/ecdsa"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -10,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	ultron "github.com/be-heroes/ultron/pkg"
 	services "github.com/be-heroes/ultron/pkg/services"
 	"github.com/stretchr/testify/assert"
 )
@@ -118,3 +120,93 @@ func TestExportCACert_FailToWriteFile(t *testing.T) {
 	// Assert
 	assert.Error(t, err, "Expected error for invalid file path, but got none")
 }
+
+func TestGenerateCertificateAuthority_Success(t *testing.T) {
+	// Arrange
+	certService := services.NewCertificateService()
+
+	// Act
+	rsaAuthority, rsaErr := certService.GenerateCertificateAuthority("TestOrg", "test-ca", ultron.KeyAlgorithmRsa, 24*time.Hour)
+	ecdsaAuthority, ecdsaErr := certService.GenerateCertificateAuthority("TestOrg", "test-ca", ultron.KeyAlgorithmEcdsa, 24*time.Hour)
+
+	// Assert
+	assert.NoError(t, rsaErr)
+	assert.NoError(t, ecdsaErr)
+	assert.True(t, rsaAuthority.Certificate.IsCA, "Expected a CA certificate")
+	assert.NotZero(t, rsaAuthority.Certificate.KeyUsage&x509.KeyUsageCertSign, "Expected the CA to be allowed to sign certificates")
+	assert.IsType(t, &rsa.PrivateKey{}, rsaAuthority.PrivateKey)
+	assert.IsType(t, &ecdsa.PrivateKey{}, ecdsaAuthority.PrivateKey)
+}
+
+func TestGenerateCertificateAuthority_UnsupportedKeyAlgorithm(t *testing.T) {
+	// Arrange
+	certService := services.NewCertificateService()
+
+	// Act
+	_, err := certService.GenerateCertificateAuthority("TestOrg", "test-ca", ultron.KeyAlgorithm("dsa"), 24*time.Hour)
+
+	// Assert
+	assert.Error(t, err, "Expected an error for an unsupported key algorithm")
+}
+
+func TestIssueServingCert_Success(t *testing.T) {
+	// Arrange
+	certService := services.NewCertificateService()
+	authority, _ := certService.GenerateCertificateAuthority("TestOrg", "test-ca", ultron.KeyAlgorithmEcdsa, 24*time.Hour)
+
+	// Act
+	cert, err := certService.IssueServingCert(authority, "TestOrg", "test.com", []string{"test.com"}, []net.IP{net.ParseIP("127.0.0.1")}, ultron.KeyAlgorithmEcdsa, time.Hour)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, cert.Leaf.IsCA, "Expected a serving certificate rather than a CA")
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}, cert.Leaf.ExtKeyUsage)
+	assert.NoError(t, cert.Leaf.CheckSignatureFrom(authority.Certificate), "Expected the certificate to be signed by the CA")
+	assert.WithinDuration(t, time.Now().Add(time.Hour), cert.Leaf.NotAfter, time.Minute)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(authority.Certificate)
+
+	_, verifyErr := cert.Leaf.Verify(x509.VerifyOptions{DNSName: "test.com", Roots: roots})
+	assert.NoError(t, verifyErr, "Expected the certificate to verify against the CA")
+}
+
+func TestIssueServingCert_CappedByAuthority(t *testing.T) {
+	// Arrange
+	certService := services.NewCertificateService()
+	authority, _ := certService.GenerateCertificateAuthority("TestOrg", "test-ca", ultron.KeyAlgorithmRsa, time.Hour)
+
+	// Act
+	cert, err := certService.IssueServingCert(authority, "TestOrg", "test.com", nil, nil, ultron.KeyAlgorithmRsa, 24*time.Hour)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, authority.Certificate.NotAfter, cert.Leaf.NotAfter, "Expected the certificate not to outlive the CA")
+}
+
+func TestIssueServingCert_MissingAuthority(t *testing.T) {
+	// Arrange
+	certService := services.NewCertificateService()
+
+	// Act
+	_, err := certService.IssueServingCert(nil, "TestOrg", "test.com", nil, nil, ultron.KeyAlgorithmRsa, time.Hour)
+
+	// Assert
+	assert.Error(t, err, "Expected an error without a certificate authority")
+}
+
+func TestEncodeDecodeCertificateAuthority(t *testing.T) {
+	// Arrange
+	certService := services.NewCertificateService()
+	authority, _ := certService.GenerateCertificateAuthority("TestOrg", "test-ca", ultron.KeyAlgorithmEcdsa, time.Hour)
+
+	// Act
+	certPEM, keyPEM, err := services.EncodeCertificateAuthority(authority)
+	decoded, decodeErr := services.DecodeCertificateAuthority(certPEM, keyPEM)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, decodeErr)
+	assert.Equal(t, authority.Certificate.Raw, decoded.Certificate.Raw)
+	assert.True(t, authority.PrivateKey.(*ecdsa.PrivateKey).Equal(decoded.PrivateKey))
+}
@@ -0,0 +1,141 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"strings"
+	"sync"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type ICertificateSource interface {
+	AddRotationHandler(handler func(ctx context.Context, caBundle []byte) error)
+	Certificate() (*tls.Certificate, error)
+	GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error)
+	Rotate(ctx context.Context) error
+	Start(ctx context.Context) error
+}
+
+func NewCertificateSourceFromConfig(config *ultron.Config, kubernetesConfig *rest.Config, certificateService ICertificateService) (ICertificateSource, error) {
+	switch config.CertificateSource {
+	case "", ultron.CertificateSourceSelfSigned:
+		authorityStore, err := NewCertificateAuthorityStoreFromConfig(config, kubernetesConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewCertificateManager(certificateService, authorityStore, config), nil
+	case ultron.CertificateSourceFile:
+		if config.CertificateLocation == "" {
+			return nil, fmt.Errorf("certificate location must be a directory for the file source")
+		}
+
+		return NewFileCertificateSource(config.CertificateLocation), nil
+	case ultron.CertificateSourceSecret:
+		namespace, name, found := strings.Cut(config.CertificateLocation, "/")
+		if !found || namespace == "" || name == "" {
+			return nil, fmt.Errorf("secret location must be in the format namespace/name: %s", config.CertificateLocation)
+		}
+
+		clientSet, err := kubernetes.NewForConfig(kubernetesConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSecretCertificateSource(clientSet, namespace, name), nil
+	default:
+		return nil, fmt.Errorf("unsupported certificate source: %s", config.CertificateSource)
+	}
+}
+
+// certificateHolder serves the current certificate and notifies rotation handlers whenever the
+// CA bundle clients need to trust changes.
+type certificateHolder struct {
+	mutex         sync.RWMutex
+	certificate   *tls.Certificate
+	handlersMutex sync.Mutex
+	handlers      []func(ctx context.Context, caBundle []byte) error
+	caBundle      []byte
+}
+
+func (h *certificateHolder) AddRotationHandler(handler func(ctx context.Context, caBundle []byte) error) {
+	h.handlersMutex.Lock()
+	defer h.handlersMutex.Unlock()
+
+	h.handlers = append(h.handlers, handler)
+}
+
+func (h *certificateHolder) Certificate() (*tls.Certificate, error) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if h.certificate == nil {
+		return nil, fmt.Errorf("no certificate has been loaded yet")
+	}
+
+	return h.certificate, nil
+}
+
+// GetCertificate is meant for tls.Config so every handshake picks up the latest rotation.
+func (h *certificateHolder) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return h.Certificate()
+}
+
+func (h *certificateHolder) swap(ctx context.Context, certificate *tls.Certificate, caBundle []byte) error {
+	h.mutex.Lock()
+	h.certificate = certificate
+	h.mutex.Unlock()
+
+	metrics.CertificateRotationsTotal.WithLabelValues(metrics.RotationResultSuccess).Inc()
+	metrics.CertificateNotAfter.Set(float64(certificate.Leaf.NotAfter.Unix()))
+
+	return h.publish(ctx, caBundle)
+}
+
+func (h *certificateHolder) publish(ctx context.Context, caBundle []byte) error {
+	h.handlersMutex.Lock()
+	defer h.handlersMutex.Unlock()
+
+	// Handlers register the bundle clients trust, so a source without one cannot satisfy them.
+	if len(caBundle) == 0 && len(h.handlers) > 0 {
+		return fmt.Errorf("no CA bundle to publish to %d rotation handlers", len(h.handlers))
+	}
+
+	if len(caBundle) == 0 || bytes.Equal(caBundle, h.caBundle) {
+		return nil
+	}
+
+	for _, handler := range h.handlers {
+		if err := handler(ctx, caBundle); err != nil {
+			return err
+		}
+	}
+
+	h.caBundle = caBundle
+
+	return nil
+}
+
+func parseServingCertificate(certPEM []byte, keyPEM []byte) (*tls.Certificate, error) {
+	certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key pair: %w", err)
+	}
+
+	if certificate.Leaf == nil {
+		certificate.Leaf, err = x509.ParseCertificate(certificate.Certificate[0])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate: %w", err)
+		}
+	}
+
+	return &certificate, nil
+}
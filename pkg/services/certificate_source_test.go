@@ -0,0 +1,56 @@
+package services_test
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func newServingCertificatePem(t *testing.T) ([]byte, []byte, []byte) {
+	t.Helper()
+
+	certificateService := services.NewCertificateService()
+	authority, err := certificateService.GenerateCertificateAuthority("TestOrg", "test-ca", ultron.KeyAlgorithmEcdsa, time.Hour)
+	assert.NoError(t, err)
+
+	certificate, err := certificateService.IssueServingCert(authority, "TestOrg", "test.com", []string{"test.com"}, nil, ultron.KeyAlgorithmEcdsa, time.Hour)
+	assert.NoError(t, err)
+
+	keyDERBytes, err := x509.MarshalPKCS8PrivateKey(certificate.PrivateKey)
+	assert.NoError(t, err)
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: ultron.BlockTypeCertificate, Bytes: certificate.Certificate[0]})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: ultron.BlockTypePrivateKey, Bytes: keyDERBytes})
+
+	return certPEM, keyPEM, authority.CertificatePem()
+}
+
+func TestNewCertificateSourceFromConfig(t *testing.T) {
+	// Arrange
+	certificateService := services.NewCertificateService()
+	fileConfig := &ultron.Config{CertificateSource: ultron.CertificateSourceFile, CertificateLocation: t.TempDir()}
+	missingFileLocationConfig := &ultron.Config{CertificateSource: ultron.CertificateSourceFile}
+	invalidSecretConfig := &ultron.Config{CertificateSource: ultron.CertificateSourceSecret, CertificateLocation: "ultron-tls"}
+	unsupportedConfig := &ultron.Config{CertificateSource: "acme"}
+
+	// Act
+	selfSignedSource, selfSignedErr := services.NewCertificateSourceFromConfig(&ultron.Config{CertificateSource: ultron.CertificateSourceSelfSigned}, nil, certificateService)
+	fileSource, fileErr := services.NewCertificateSourceFromConfig(fileConfig, nil, certificateService)
+	_, missingFileLocationErr := services.NewCertificateSourceFromConfig(missingFileLocationConfig, nil, certificateService)
+	_, invalidSecretErr := services.NewCertificateSourceFromConfig(invalidSecretConfig, nil, certificateService)
+	_, unsupportedErr := services.NewCertificateSourceFromConfig(unsupportedConfig, nil, certificateService)
+
+	// Assert
+	assert.NoError(t, selfSignedErr)
+	assert.IsType(t, &services.CertificateManager{}, selfSignedSource)
+	assert.NoError(t, fileErr)
+	assert.IsType(t, &services.FileCertificateSource{}, fileSource)
+	assert.Error(t, missingFileLocationErr, "Expected an error for a file source without a directory")
+	assert.Error(t, invalidSecretErr, "Expected an error for a secret location without a namespace")
+	assert.Error(t, unsupportedErr, "Expected an error for an unsupported source")
+}
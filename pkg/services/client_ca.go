@@ -0,0 +1,181 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type IClientCa interface {
+	CertPool() (*x509.CertPool, error)
+	Load(ctx context.Context) error
+	Start(ctx context.Context, interval time.Duration) error
+}
+
+// ClientCa holds the CA bundle admission clients are verified against, typically the one the
+// API server's front-proxy or webhook client certificate is issued from.
+type ClientCa struct {
+	read     func(ctx context.Context) ([]byte, error)
+	mutex    sync.RWMutex
+	caBundle []byte
+	certPool *x509.CertPool
+}
+
+func NewFileClientCa(path string) *ClientCa {
+	return &ClientCa{
+		read: func(ctx context.Context) ([]byte, error) {
+			caBundle, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read client CA bundle %s: %w", path, err)
+			}
+
+			return caBundle, nil
+		},
+	}
+}
+
+func NewSecretClientCa(clientSet kubernetes.Interface, namespace string, name string) *ClientCa {
+	return &ClientCa{
+		read: func(ctx context.Context) ([]byte, error) {
+			secret, err := clientSet.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return nil, fmt.Errorf("failed to get secret %s/%s: %w", namespace, name, err)
+			}
+
+			caBundle, found := secret.Data[certificateAuthorityCertFile]
+			if !found {
+				return nil, fmt.Errorf("secret %s/%s has no %s key", namespace, name, certificateAuthorityCertFile)
+			}
+
+			return caBundle, nil
+		},
+	}
+}
+
+func NewClientCaFromConfig(config *ultron.Config, kubernetesConfig *rest.Config) (IClientCa, error) {
+	switch config.ClientCaSource {
+	case "":
+		return nil, nil
+	case ultron.ClientCaSourceFile:
+		if config.ClientCaLocation == "" {
+			return nil, fmt.Errorf("client CA location must be a file for the file source")
+		}
+
+		return NewFileClientCa(config.ClientCaLocation), nil
+	case ultron.ClientCaSourceSecret:
+		namespace, name, found := strings.Cut(config.ClientCaLocation, "/")
+		if !found || namespace == "" || name == "" {
+			return nil, fmt.Errorf("secret location must be in the format namespace/name: %s", config.ClientCaLocation)
+		}
+
+		clientSet, err := kubernetes.NewForConfig(kubernetesConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewSecretClientCa(clientSet, namespace, name), nil
+	default:
+		return nil, fmt.Errorf("unsupported client CA source: %s", config.ClientCaSource)
+	}
+}
+
+func (c *ClientCa) CertPool() (*x509.CertPool, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.certPool == nil {
+		return nil, fmt.Errorf("no client CA bundle has been loaded yet")
+	}
+
+	return c.certPool, nil
+}
+
+func (c *ClientCa) Load(ctx context.Context) error {
+	caBundle, err := c.read(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.mutex.RLock()
+	unchanged := bytes.Equal(caBundle, c.caBundle)
+	c.mutex.RUnlock()
+
+	if unchanged {
+		return nil
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(caBundle) {
+		return fmt.Errorf("client CA bundle contains no certificates")
+	}
+
+	c.mutex.Lock()
+	c.caBundle = caBundle
+	c.certPool = certPool
+	c.mutex.Unlock()
+
+	return nil
+}
+
+func (c *ClientCa) Start(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+
+		if err := c.Load(ctx); err != nil {
+			log.Printf("Could not reload client CA bundle: %v", err)
+		}
+	}
+}
+
+// NewServerTlsConfig serves the current certificate and, when a client CA is given, verifies any
+// client certificate presented against its latest bundle. Clients without a certificate, such as
+// kubelet probes, still connect; admission and explain routes reject them via ClientAuthHandler.
+func NewServerTlsConfig(certificateSource ICertificateSource, clientCa IClientCa) *tls.Config {
+	// http.Server only sets NextProtos on its own copy of the config, so the per-client config
+	// cloned below needs them here to keep negotiating HTTP/2.
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: certificateSource.GetCertificate,
+		NextProtos:     []string{"h2", "http/1.1"},
+	}
+
+	if clientCa == nil {
+		return tlsConfig
+	}
+
+	tlsConfig.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+		certPool, err := clientCa.CertPool()
+		if err != nil {
+			return nil, err
+		}
+
+		clientConfig := tlsConfig.Clone()
+		clientConfig.GetConfigForClient = nil
+		clientConfig.ClientAuth = tls.VerifyClientCertIfGiven
+		clientConfig.ClientCAs = certPool
+
+		return clientConfig, nil
+	}
+
+	return tlsConfig
+}
@@ -0,0 +1,140 @@
+package services_test
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewClientCaFromConfig(t *testing.T) {
+	// Arrange
+	fileConfig := &ultron.Config{ClientCaSource: ultron.ClientCaSourceFile, ClientCaLocation: "/etc/ultron/client-ca.crt"}
+	missingFileLocationConfig := &ultron.Config{ClientCaSource: ultron.ClientCaSourceFile}
+	invalidSecretConfig := &ultron.Config{ClientCaSource: ultron.ClientCaSourceSecret, ClientCaLocation: "client-ca"}
+	unsupportedConfig := &ultron.Config{ClientCaSource: "oidc"}
+
+	// Act
+	disabledClientCa, disabledErr := services.NewClientCaFromConfig(&ultron.Config{}, nil)
+	fileClientCa, fileErr := services.NewClientCaFromConfig(fileConfig, nil)
+	_, missingFileLocationErr := services.NewClientCaFromConfig(missingFileLocationConfig, nil)
+	_, invalidSecretErr := services.NewClientCaFromConfig(invalidSecretConfig, nil)
+	_, unsupportedErr := services.NewClientCaFromConfig(unsupportedConfig, nil)
+
+	// Assert
+	assert.NoError(t, disabledErr)
+	assert.Nil(t, disabledClientCa, "Expected no client CA when no source is configured")
+	assert.NoError(t, fileErr)
+	assert.IsType(t, &services.ClientCa{}, fileClientCa)
+	assert.Error(t, missingFileLocationErr, "Expected an error for a file source without a path")
+	assert.Error(t, invalidSecretErr, "Expected an error for a secret location without a namespace")
+	assert.Error(t, unsupportedErr, "Expected an error for an unsupported source")
+}
+
+func TestClientCa_CertPoolBeforeLoad(t *testing.T) {
+	// Arrange
+	clientCa := services.NewFileClientCa(filepath.Join(t.TempDir(), "ca.crt"))
+
+	// Act
+	certPool, err := clientCa.CertPool()
+
+	// Assert
+	assert.Error(t, err, "Expected an error before any bundle is loaded")
+	assert.Nil(t, certPool)
+}
+
+func TestFileClientCa_Load(t *testing.T) {
+	// Arrange
+	_, _, caPEM := newServingCertificatePem(t)
+	path := filepath.Join(t.TempDir(), "ca.crt")
+	_ = os.WriteFile(path, caPEM, 0600)
+
+	clientCa := services.NewFileClientCa(path)
+
+	// Act
+	err := clientCa.Load(context.Background())
+	certPool, certPoolErr := clientCa.CertPool()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, certPoolErr)
+	assert.NotNil(t, certPool)
+}
+
+func TestFileClientCa_LoadInvalidBundleKeepsCurrentPool(t *testing.T) {
+	// Arrange
+	_, _, caPEM := newServingCertificatePem(t)
+	path := filepath.Join(t.TempDir(), "ca.crt")
+	_ = os.WriteFile(path, caPEM, 0600)
+
+	clientCa := services.NewFileClientCa(path)
+	_ = clientCa.Load(context.Background())
+	current, _ := clientCa.CertPool()
+
+	_ = os.WriteFile(path, []byte("not a certificate"), 0600)
+
+	// Act
+	err := clientCa.Load(context.Background())
+	certPool, _ := clientCa.CertPool()
+
+	// Assert
+	assert.Error(t, err, "Expected an error for a bundle without certificates")
+	assert.Same(t, current, certPool, "Expected the previous bundle to keep verifying clients")
+}
+
+func TestSecretClientCa_Load(t *testing.T) {
+	// Arrange
+	_, _, caPEM := newServingCertificatePem(t)
+	clientSet := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "client-ca", Namespace: "kube-system"},
+		Data:       map[string][]byte{"ca.crt": caPEM},
+	})
+	clientCa := services.NewSecretClientCa(clientSet, "kube-system", "client-ca")
+	missingClientCa := services.NewSecretClientCa(clientSet, "kube-system", "missing")
+
+	// Act
+	err := clientCa.Load(context.Background())
+	_, certPoolErr := clientCa.CertPool()
+	missingErr := missingClientCa.Load(context.Background())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, certPoolErr)
+	assert.Error(t, missingErr, "Expected an error for a missing secret")
+}
+
+func TestNewServerTlsConfig(t *testing.T) {
+	// Arrange
+	certPEM, keyPEM, caPEM := newServingCertificatePem(t)
+	directory := t.TempDir()
+	_ = os.WriteFile(filepath.Join(directory, corev1.TLSCertKey), certPEM, 0600)
+	_ = os.WriteFile(filepath.Join(directory, corev1.TLSPrivateKeyKey), keyPEM, 0600)
+	_ = os.WriteFile(filepath.Join(directory, "ca.crt"), caPEM, 0600)
+
+	certificateSource := services.NewFileCertificateSource(directory)
+	clientCa := services.NewFileClientCa(filepath.Join(directory, "ca.crt"))
+	_ = clientCa.Load(context.Background())
+
+	// Act
+	plainConfig := services.NewServerTlsConfig(certificateSource, nil)
+	mutualConfig := services.NewServerTlsConfig(certificateSource, clientCa)
+	clientConfig, err := mutualConfig.GetConfigForClient(&tls.ClientHelloInfo{})
+
+	// Assert
+	assert.NotNil(t, plainConfig.GetCertificate)
+	assert.Nil(t, plainConfig.GetConfigForClient, "Expected no client verification without a client CA")
+	assert.NoError(t, err)
+	assert.Equal(t, tls.VerifyClientCertIfGiven, clientConfig.ClientAuth, "Expected clients without a certificate to still connect")
+	assert.NotNil(t, clientConfig.ClientCAs)
+	assert.Equal(t, mutualConfig.NextProtos, clientConfig.NextProtos, "Expected HTTP/2 to still be negotiated with a client CA")
+	assert.Contains(t, clientConfig.NextProtos, "h2")
+}
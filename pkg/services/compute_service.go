@@ -1,27 +1,38 @@
 package services
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"math"
 	"slices"
 	"sort"
+	"strconv"
+	"strings"
 
 	ultron "github.com/be-heroes/ultron/pkg"
 	algorithm "github.com/be-heroes/ultron/pkg/algorithm"
 	mapper "github.com/be-heroes/ultron/pkg/mapper"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+	tracing "github.com/be-heroes/ultron/pkg/tracing"
 
 	corev1 "k8s.io/api/core/v1"
 )
 
 type IComputeService interface {
-	MatchPodSpec(pod *corev1.Pod) (*ultron.WeightedNode, error)
-	MatchWeightedPodToComputeConfiguration(wPod *ultron.WeightedPod) (*ultron.ComputeConfiguration, error)
-	MatchWeightedNodeToComputeConfiguration(wNode *ultron.WeightedNode) (*ultron.ComputeConfiguration, error)
-	MatchWeightedPodToWeightedNode(wPod *ultron.WeightedPod) (*ultron.WeightedNode, error)
-	CalculateWeightedNodeMedianPrice(wNode *ultron.WeightedNode) (float64, error)
+	MatchPodSpec(ctx context.Context, pod *corev1.Pod) (*ultron.WeightedNode, error)
+	RankPodSpec(ctx context.Context, pod *corev1.Pod, limit int) ([]ultron.RankedWeightedNode, error)
+	ExplainPodSpec(ctx context.Context, pod *corev1.Pod) ([]ultron.CandidateExplanation, error)
+	ValidatePodSpec(ctx context.Context, pod *corev1.Pod) ([]ultron.ConstraintViolation, error)
+	MatchWeightedPodToComputeConfiguration(ctx context.Context, wPod *ultron.WeightedPod) (*ultron.ComputeConfiguration, error)
+	MatchWeightedNodeToComputeConfiguration(ctx context.Context, wNode *ultron.WeightedNode) (*ultron.ComputeConfiguration, error)
+	MatchWeightedPodToWeightedNode(ctx context.Context, wPod *ultron.WeightedPod) (*ultron.WeightedNode, error)
+	RankWeightedPodToWeightedNodes(ctx context.Context, wPod *ultron.WeightedPod, limit int) ([]ultron.RankedWeightedNode, error)
+	CalculateWeightedNodeMedianPrice(ctx context.Context, wNode *ultron.WeightedNode) (float64, error)
 	ComputeConfigurationMatchesWeightedNodeRequirements(computeConfiguration *ultron.ComputeConfiguration, wNode *ultron.WeightedNode) bool
 	ComputeConfigurationMatchesWeightedPodRequirements(computeConfiguration *ultron.ComputeConfiguration, wPod *ultron.WeightedPod) bool
-	GetInteruptionRateForWeightedNode(wNode *ultron.WeightedNode) (*ultron.WeightedInteruptionRate, error)
-	GetLatencyRateForWeightedNode(wNode *ultron.WeightedNode) (*ultron.WeightedLatencyRate, error)
+	GetInteruptionRateForWeightedNode(ctx context.Context, wNode *ultron.WeightedNode) (*ultron.WeightedInteruptionRate, error)
+	GetLatencyRateForWeightedNode(ctx context.Context, wNode *ultron.WeightedNode) (*ultron.WeightedLatencyRate, error)
 }
 
 type ComputeService struct {
@@ -38,20 +49,23 @@ func NewComputeService(algorithm algorithm.IAlgorithm, cacheService ICacheServic
 	}
 }
 
-func (cs *ComputeService) MatchPodSpec(pod *corev1.Pod) (*ultron.WeightedNode, error) {
+func (cs *ComputeService) MatchPodSpec(ctx context.Context, pod *corev1.Pod) (*ultron.WeightedNode, error) {
+	ctx, span := tracing.StartSpan(ctx, "ComputeService.MatchPodSpec")
+	defer span.End()
+
 	wPod, err := cs.mapper.MapPodToWeightedPod(pod)
 	if err != nil {
 		return nil, err
 	}
 
-	wNode, err := cs.MatchWeightedPodToWeightedNode(&wPod)
+	wNode, err := cs.MatchWeightedPodToWeightedNode(ctx, &wPod)
 	if err != nil {
 		return nil, err
 	}
 
 	if wNode == nil {
-		computeConfiguration, err := cs.MatchWeightedPodToComputeConfiguration(&wPod)
-		if err != nil {
+		computeConfiguration, err := cs.MatchWeightedPodToComputeConfiguration(ctx, &wPod)
+		if err != nil && !errors.Is(err, ErrCacheKeyNotFound) {
 			return nil, err
 		}
 
@@ -64,8 +78,10 @@ func (cs *ComputeService) MatchPodSpec(pod *corev1.Pod) (*ultron.WeightedNode, e
 				instanceType = ultron.DefaultEphemeralInstanceType
 			}
 
+			// Placeholder instance types never exist on a node, so the fallback selects the compute type
+			// label that provisioned node pools carry instead.
 			wNode = &ultron.WeightedNode{
-				Selector: map[string]string{ultron.LabelInstanceType: instanceType, ultron.AnnotationManaged: "true"},
+				Selector: map[string]string{ultron.LabelComputeType: string(computeConfiguration.ComputeType)},
 				Weights: map[string]float64{
 					ultron.WeightKeyCpuAvailable:     float64(*computeConfiguration.VCpu),
 					ultron.WeightKeyCpuTotal:         float64(*computeConfiguration.VCpu),
@@ -81,23 +97,15 @@ func (cs *ComputeService) MatchPodSpec(pod *corev1.Pod) (*ultron.WeightedNode, e
 				},
 			}
 
-			interuptionRate, err := cs.GetInteruptionRateForWeightedNode(wNode)
-			if err != nil {
-				return nil, err
-			}
-
-			if interuptionRate != nil {
+			interuptionRate, err := cs.GetInteruptionRateForWeightedNode(ctx, wNode)
+			if err == nil && interuptionRate != nil {
 				wNode.InterruptionRate = *interuptionRate
 			} else {
 				wNode.InterruptionRate = ultron.WeightedInteruptionRate{Weight: -1}
 			}
 
-			latencyRate, err := cs.GetLatencyRateForWeightedNode(wNode)
-			if err != nil {
-				return nil, err
-			}
-
-			if interuptionRate != nil {
+			latencyRate, err := cs.GetLatencyRateForWeightedNode(ctx, wNode)
+			if err == nil && latencyRate != nil {
 				wNode.LatencyRate = *latencyRate
 			} else {
 				wNode.LatencyRate = ultron.WeightedLatencyRate{Weight: -1}
@@ -108,9 +116,159 @@ func (cs *ComputeService) MatchPodSpec(pod *corev1.Pod) (*ultron.WeightedNode, e
 	return wNode, nil
 }
 
-func (cs *ComputeService) MatchWeightedPodToComputeConfiguration(wPod *ultron.WeightedPod) (*ultron.ComputeConfiguration, error) {
+func (cs *ComputeService) RankPodSpec(ctx context.Context, pod *corev1.Pod, limit int) ([]ultron.RankedWeightedNode, error) {
+	ctx, span := tracing.StartSpan(ctx, "ComputeService.RankPodSpec")
+	defer span.End()
+
+	wPod, err := cs.mapper.MapPodToWeightedPod(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	return cs.RankWeightedPodToWeightedNodes(ctx, &wPod, limit)
+}
+
+func (cs *ComputeService) ExplainPodSpec(ctx context.Context, pod *corev1.Pod) ([]ultron.CandidateExplanation, error) {
+	ctx, span := tracing.StartSpan(ctx, "ComputeService.ExplainPodSpec")
+	defer span.End()
+
+	wPod, err := cs.mapper.MapPodToWeightedPod(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	wNodes, err := cs.cacheService.GetWeightedNodes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := []ultron.CandidateExplanation{}
+
+	for _, wNode := range wNodes {
+		candidate := ultron.CandidateExplanation{
+			Selector:    wNode.Selector,
+			Eligible:    true,
+			Explanation: cs.algorithm.Explain(&wNode, &wPod),
+		}
+
+		if wNode.Weights[ultron.WeightKeyCpuAvailable] < wPod.Weights[ultron.WeightKeyCpuRequested] {
+			candidate.Eligible = false
+			candidate.Reason = fmt.Sprintf("insufficient cpu: %v available, %v requested", wNode.Weights[ultron.WeightKeyCpuAvailable], wPod.Weights[ultron.WeightKeyCpuRequested])
+		} else if wNode.Weights[ultron.WeightKeyMemoryAvailable] < wPod.Weights[ultron.WeightKeyMemoryRequested] {
+			candidate.Eligible = false
+			candidate.Reason = fmt.Sprintf("insufficient memory: %v available, %v requested", wNode.Weights[ultron.WeightKeyMemoryAvailable], wPod.Weights[ultron.WeightKeyMemoryRequested])
+		}
+
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Eligible != candidates[j].Eligible {
+			return candidates[i].Eligible
+		}
+
+		return candidates[i].Explanation.TotalScore > candidates[j].Explanation.TotalScore
+	})
+
+	for i := range candidates {
+		if candidates[i].Eligible {
+			candidates[i].Rank = i + 1
+		}
+	}
+
+	return candidates, nil
+}
+
+func (cs *ComputeService) ValidatePodSpec(ctx context.Context, pod *corev1.Pod) ([]ultron.ConstraintViolation, error) {
+	ctx, span := tracing.StartSpan(ctx, "ComputeService.ValidatePodSpec")
+	defer span.End()
+
+	violations := []ultron.ConstraintViolation{}
+
+	for _, annotation := range []string{ultron.AnnotationDiskType, ultron.AnnotationNetworkType} {
+		if value, exists := pod.Annotations[annotation]; exists && strings.TrimSpace(value) == "" {
+			violations = append(violations, ultron.ConstraintViolation{Annotation: annotation, Value: value, Malformed: true, Reason: "must not be empty"})
+		}
+	}
+
+	storageSize, storageSizeExists := pod.Annotations[ultron.AnnotationStorageSizeGb]
+	if storageSizeExists {
+		if value, err := strconv.ParseFloat(storageSize, 64); err != nil || value <= 0 || math.IsInf(value, 0) {
+			violations = append(violations, ultron.ConstraintViolation{Annotation: ultron.AnnotationStorageSizeGb, Value: storageSize, Malformed: true, Reason: "must be a positive number"})
+		}
+	}
+
+	if len(violations) > 0 {
+		return violations, nil
+	}
+
+	wNodes, err := cs.cacheService.GetWeightedNodes(ctx)
+	if err != nil && !errors.Is(err, ErrCacheKeyNotFound) {
+		return nil, err
+	}
+
+	computeConfigurations, err := cs.cacheService.GetAllComputeConfigurations(ctx)
+	if err != nil && !errors.Is(err, ErrCacheKeyNotFound) {
+		return nil, err
+	}
+
+	if len(wNodes) == 0 && len(computeConfigurations) == 0 {
+		return violations, nil
+	}
+
+	diskType, diskTypeExists := pod.Annotations[ultron.AnnotationDiskType]
+	networkType, networkTypeExists := pod.Annotations[ultron.AnnotationNetworkType]
+	storageSizeGb, _ := strconv.ParseFloat(storageSize, 64)
+
+	diskTypeSatisfied, networkTypeSatisfied, storageSizeSatisfied, combinedSatisfied := !diskTypeExists, !networkTypeExists, !storageSizeExists, false
+
+	for _, wNode := range wNodes {
+		diskTypeMatches := !diskTypeExists || wNode.Annotations[ultron.AnnotationDiskType] == diskType
+		networkTypeMatches := !networkTypeExists || wNode.Annotations[ultron.AnnotationNetworkType] == networkType
+		storageSizeMatches := !storageSizeExists || wNode.Weights[ultron.WeightKeyStorageAvailable] >= storageSizeGb
+
+		diskTypeSatisfied = diskTypeSatisfied || diskTypeMatches
+		networkTypeSatisfied = networkTypeSatisfied || networkTypeMatches
+		storageSizeSatisfied = storageSizeSatisfied || storageSizeMatches
+		combinedSatisfied = combinedSatisfied || (diskTypeMatches && networkTypeMatches && storageSizeMatches)
+	}
+
+	for _, computeConfiguration := range computeConfigurations {
+		diskTypeMatches := !diskTypeExists || (computeConfiguration.VolumeType != nil && *computeConfiguration.VolumeType == diskType)
+		networkTypeMatches := !networkTypeExists || slices.Contains(computeConfiguration.CloudNetworkTypes, networkType)
+		storageSizeMatches := !storageSizeExists || (computeConfiguration.VolumeGb != nil && float64(*computeConfiguration.VolumeGb) >= storageSizeGb)
+
+		diskTypeSatisfied = diskTypeSatisfied || diskTypeMatches
+		networkTypeSatisfied = networkTypeSatisfied || networkTypeMatches
+		storageSizeSatisfied = storageSizeSatisfied || storageSizeMatches
+		combinedSatisfied = combinedSatisfied || (diskTypeMatches && networkTypeMatches && storageSizeMatches)
+	}
+
+	if !diskTypeSatisfied {
+		violations = append(violations, ultron.ConstraintViolation{Annotation: ultron.AnnotationDiskType, Value: diskType, Reason: "no node or compute configuration provides this disk type"})
+	}
+
+	if !networkTypeSatisfied {
+		violations = append(violations, ultron.ConstraintViolation{Annotation: ultron.AnnotationNetworkType, Value: networkType, Reason: "no node or compute configuration provides this network type"})
+	}
+
+	if !storageSizeSatisfied {
+		violations = append(violations, ultron.ConstraintViolation{Annotation: ultron.AnnotationStorageSizeGb, Value: storageSize, Reason: "no node or compute configuration provides this much storage"})
+	}
+
+	if len(violations) == 0 && !combinedSatisfied {
+		violations = append(violations, ultron.ConstraintViolation{Reason: "no single node or compute configuration satisfies all constraints"})
+	}
+
+	return violations, nil
+}
+
+func (cs *ComputeService) MatchWeightedPodToComputeConfiguration(ctx context.Context, wPod *ultron.WeightedPod) (*ultron.ComputeConfiguration, error) {
+	ctx, span := tracing.StartSpan(ctx, "ComputeService.MatchWeightedPodToComputeConfiguration")
+	defer span.End()
+
 	var suitableConfigs []ultron.ComputeConfiguration
-	computeConfigurations, err := cs.cacheService.GetAllComputeConfigurations()
+	computeConfigurations, err := cs.cacheService.GetAllComputeConfigurations(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -132,9 +290,9 @@ func (cs *ComputeService) MatchWeightedPodToComputeConfiguration(wPod *ultron.We
 	return &suitableConfigs[0], nil
 }
 
-func (cs *ComputeService) MatchWeightedNodeToComputeConfiguration(wNode *ultron.WeightedNode) (*ultron.ComputeConfiguration, error) {
+func (cs *ComputeService) MatchWeightedNodeToComputeConfiguration(ctx context.Context, wNode *ultron.WeightedNode) (*ultron.ComputeConfiguration, error) {
 	var suitableConfigs []ultron.ComputeConfiguration
-	computeConfigurations, err := cs.cacheService.GetAllComputeConfigurations()
+	computeConfigurations, err := cs.cacheService.GetAllComputeConfigurations(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -156,35 +314,66 @@ func (cs *ComputeService) MatchWeightedNodeToComputeConfiguration(wNode *ultron.
 	return &suitableConfigs[0], nil
 }
 
-func (cs *ComputeService) MatchWeightedPodToWeightedNode(pod *ultron.WeightedPod) (*ultron.WeightedNode, error) {
-	wNodes, err := cs.cacheService.GetWeightedNodes()
+func (cs *ComputeService) MatchWeightedPodToWeightedNode(ctx context.Context, pod *ultron.WeightedPod) (*ultron.WeightedNode, error) {
+	rankedNodes, err := cs.RankWeightedPodToWeightedNodes(ctx, pod, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rankedNodes) == 0 {
+		return nil, nil
+	}
+
+	return &rankedNodes[0].Node, nil
+}
+
+func (cs *ComputeService) RankWeightedPodToWeightedNodes(ctx context.Context, pod *ultron.WeightedPod, limit int) ([]ultron.RankedWeightedNode, error) {
+	ctx, span := tracing.StartSpan(ctx, "ComputeService.RankWeightedPodToWeightedNodes")
+	defer span.End()
+
+	wNodes, err := cs.cacheService.GetWeightedNodes(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var match ultron.WeightedNode
-	highestScore := math.Inf(-1)
+	rankedNodes := []ultron.RankedWeightedNode{}
+	scores := make([]float64, 0, len(wNodes))
 
 	for _, wNode := range wNodes {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		if wNode.Weights[ultron.WeightKeyCpuAvailable] < pod.Weights[ultron.WeightKeyCpuRequested] || wNode.Weights[ultron.WeightKeyMemoryAvailable] < pod.Weights[ultron.WeightKeyMemoryRequested] {
 			continue
 		}
 
-		score := cs.algorithm.TotalScore(&wNode, pod)
+		totalScore := cs.algorithm.TotalScore(&wNode, pod)
+		scores = append(scores, totalScore)
 
-		if score > highestScore {
-			highestScore = score
-			match = wNode
-		}
+		rankedNodes = append(rankedNodes, ultron.RankedWeightedNode{
+			Node:       wNode,
+			TotalScore: totalScore,
+		})
+	}
+
+	metrics.ObserveCandidates(ctx, len(wNodes), scores)
+
+	sort.SliceStable(rankedNodes, func(i, j int) bool {
+		return rankedNodes[i].TotalScore > rankedNodes[j].TotalScore
+	})
+
+	if limit > 0 && len(rankedNodes) > limit {
+		rankedNodes = rankedNodes[:limit]
 	}
 
-	return &match, nil
+	return rankedNodes, nil
 }
 
-func (cs *ComputeService) CalculateWeightedNodeMedianPrice(wNode *ultron.WeightedNode) (float64, error) {
+func (cs *ComputeService) CalculateWeightedNodeMedianPrice(ctx context.Context, wNode *ultron.WeightedNode) (float64, error) {
 	var totalCost float64
 	var matchCount int32
-	computeConfigurations, err := cs.cacheService.GetAllComputeConfigurations()
+	computeConfigurations, err := cs.cacheService.GetAllComputeConfigurations(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -251,8 +440,8 @@ func (cs *ComputeService) ComputeConfigurationMatchesWeightedPodRequirements(com
 	return true
 }
 
-func (cs *ComputeService) GetInteruptionRateForWeightedNode(wNode *ultron.WeightedNode) (match *ultron.WeightedInteruptionRate, err error) {
-	rates, err := cs.cacheService.GetWeightedInteruptionRates()
+func (cs *ComputeService) GetInteruptionRateForWeightedNode(ctx context.Context, wNode *ultron.WeightedNode) (match *ultron.WeightedInteruptionRate, err error) {
+	rates, err := cs.cacheService.GetWeightedInteruptionRates(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -268,8 +457,8 @@ func (cs *ComputeService) GetInteruptionRateForWeightedNode(wNode *ultron.Weight
 	return match, nil
 }
 
-func (cs *ComputeService) GetLatencyRateForWeightedNode(wNode *ultron.WeightedNode) (match *ultron.WeightedLatencyRate, err error) {
-	rates, err := cs.cacheService.GetWeightedLatencyRates()
+func (cs *ComputeService) GetLatencyRateForWeightedNode(ctx context.Context, wNode *ultron.WeightedNode) (match *ultron.WeightedLatencyRate, err error) {
+	rates, err := cs.cacheService.GetWeightedLatencyRates(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -1,15 +1,19 @@
 package services_test
 
 import (
+	"context"
 	"math"
 	"testing"
 
 	"github.com/be-heroes/ultron/mocks"
 	ultron "github.com/be-heroes/ultron/pkg"
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
 	services "github.com/be-heroes/ultron/pkg/services"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func int64Ptr(i int64) *int64       { return &i }
@@ -39,7 +43,7 @@ func TestComputePodSpec_Success(t *testing.T) {
 		},
 	}, nil)
 
-	mockCache.On("GetWeightedNodes").Return([]ultron.WeightedNode{
+	mockCache.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{
 		{
 			Annotations: map[string]string{
 				ultron.AnnotationDiskType:      "SSD",
@@ -59,7 +63,7 @@ func TestComputePodSpec_Success(t *testing.T) {
 	mockAlgorithm.On("TotalScore", mock.AnythingOfType("*pkg.WeightedNode"), mock.AnythingOfType("*pkg.WeightedPod")).Return(1.0)
 
 	// Act
-	wNode, err := service.MatchPodSpec(pod)
+	wNode, err := service.MatchPodSpec(context.Background(), pod)
 
 	// Assert
 	assert.NoError(t, err)
@@ -85,22 +89,66 @@ func TestComputePodSpec_NoWeightedNode(t *testing.T) {
 
 	mockMapper.On("MapPodToWeightedPod", pod).Return(ultron.WeightedPod{}, nil)
 
-	mockCache.On("GetWeightedNodes").Return([]ultron.WeightedNode{}, nil)
+	mockCache.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{}, nil)
+	mockCache.On("GetAllComputeConfigurations", mock.Anything).Return(nil, services.ErrCacheKeyNotFound)
 
 	mockAlgorithm.On("TotalScore", mock.Anything, mock.Anything).Maybe().Return(0.0)
 
 	// Act
-	wNode, err := service.MatchPodSpec(pod)
+	wNode, err := service.MatchPodSpec(context.Background(), pod)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Empty(t, wNode, "Expected wNode to be empty when no nodes are available")
+	assert.Nil(t, wNode, "Expected no node when neither nodes nor compute configurations are available")
 
 	mockMapper.AssertExpectations(t)
 	mockCache.AssertExpectations(t)
 	mockAlgorithm.AssertExpectations(t)
 }
 
+func TestComputePodSpec_ComputeConfigurationFallback(t *testing.T) {
+	// Arrange
+	mockAlgorithm := new(mocks.IAlgorithm)
+	mockCache := new(mocks.ICacheService)
+	mockMapper := new(mocks.IMapper)
+
+	service := services.NewComputeService(mockAlgorithm, mockCache, mockMapper)
+
+	pod := &corev1.Pod{}
+
+	mockMapper.On("MapPodToWeightedPod", pod).Return(ultron.WeightedPod{
+		Annotations: map[string]string{ultron.AnnotationDiskType: "SSD", ultron.AnnotationNetworkType: "isolated"},
+	}, nil)
+	mockCache.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{}, nil)
+	mockCache.On("GetAllComputeConfigurations", mock.Anything).Return([]ultron.ComputeConfiguration{
+		{
+			ComputeType:       ultron.ComputeTypeDurable,
+			VCpu:              int64Ptr(2),
+			RamGb:             int64Ptr(8),
+			VolumeGb:          int64Ptr(50),
+			Cost:              &ultron.ComputeCost{PricePerUnit: float64Ptr(0.2)},
+			VolumeType:        stringPtr("SSD"),
+			CloudNetworkTypes: []string{"isolated"},
+		},
+	}, nil)
+	mockCache.On("GetWeightedInteruptionRates", mock.Anything).Return(nil, services.ErrCacheKeyNotFound)
+	mockCache.On("GetWeightedLatencyRates", mock.Anything).Return(nil, services.ErrCacheKeyNotFound)
+
+	fallbacks := metrics.ComputeConfigurationFallbacksTotal.WithLabelValues(ultron.DefaultDurableInstanceType)
+	before := testutil.ToFloat64(fallbacks)
+
+	// Act
+	wNode, err := service.MatchPodSpec(context.Background(), pod)
+
+	// Assert
+	assert.NoError(t, err, "Expected missing rates not to fail the fallback")
+	assert.Equal(t, before, testutil.ToFloat64(fallbacks), "Expected the fallback to be counted only when a mutation emits it")
+	assert.NotNil(t, wNode)
+	assert.Equal(t, map[string]string{ultron.LabelComputeType: string(ultron.ComputeTypeDurable)}, wNode.Selector, "Expected the fallback to select the provisioned compute type rather than a placeholder instance type")
+	assert.Equal(t, -1.0, wNode.InterruptionRate.Weight)
+	assert.Equal(t, -1.0, wNode.LatencyRate.Weight)
+}
+
 func TestMatchWeightedPodToComputeConfiguration_Success(t *testing.T) {
 	// Arrange
 	mockAlgorithm := new(mocks.IAlgorithm)
@@ -122,7 +170,7 @@ func TestMatchWeightedPodToComputeConfiguration_Success(t *testing.T) {
 		},
 	}
 
-	mockCache.On("GetAllComputeConfigurations").Return([]ultron.ComputeConfiguration{
+	mockCache.On("GetAllComputeConfigurations", mock.Anything).Return([]ultron.ComputeConfiguration{
 		{
 			ComputeType: ultron.ComputeTypeDurable,
 			VCpu:        int64Ptr(2),
@@ -137,7 +185,7 @@ func TestMatchWeightedPodToComputeConfiguration_Success(t *testing.T) {
 	}, nil)
 
 	// Act
-	computeConfig, err := service.MatchWeightedPodToComputeConfiguration(&wPod)
+	computeConfig, err := service.MatchWeightedPodToComputeConfiguration(context.Background(), &wPod)
 
 	// Assert
 	assert.NoError(t, err)
@@ -173,7 +221,7 @@ func TestCalculateWeightedNodeMedianPrice_Success(t *testing.T) {
 		},
 	}
 
-	mockCache.On("GetAllComputeConfigurations").Return([]ultron.ComputeConfiguration{
+	mockCache.On("GetAllComputeConfigurations", mock.Anything).Return([]ultron.ComputeConfiguration{
 		{
 			ComputeType: ultron.ComputeTypeDurable,
 			VCpu:        int64Ptr(2),
@@ -188,7 +236,7 @@ func TestCalculateWeightedNodeMedianPrice_Success(t *testing.T) {
 	}, nil)
 
 	// Act
-	medianPrice, err := service.CalculateWeightedNodeMedianPrice(&wNode)
+	medianPrice, err := service.CalculateWeightedNodeMedianPrice(context.Background(), &wNode)
 
 	// Assert
 	assert.NoError(t, err)
@@ -214,7 +262,7 @@ func TestMatchWeightedPodToWeightedNode_Success(t *testing.T) {
 		},
 	}
 
-	mockCache.On("GetWeightedNodes").Return([]ultron.WeightedNode{
+	mockCache.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{
 		{
 			Annotations: map[string]string{
 				ultron.AnnotationDiskType:    "SSD",
@@ -230,7 +278,7 @@ func TestMatchWeightedPodToWeightedNode_Success(t *testing.T) {
 	mockAlgorithm.On("TotalScore", mock.AnythingOfType("*pkg.WeightedNode"), mock.AnythingOfType("*pkg.WeightedPod")).Return(1.0)
 
 	// Act
-	wNode, err := service.MatchWeightedPodToWeightedNode(&wPod)
+	wNode, err := service.MatchWeightedPodToWeightedNode(context.Background(), &wPod)
 
 	// Assert
 	assert.NoError(t, err)
@@ -240,3 +288,217 @@ func TestMatchWeightedPodToWeightedNode_Success(t *testing.T) {
 	mockCache.AssertExpectations(t)
 	mockAlgorithm.AssertExpectations(t)
 }
+
+func TestExplainPodSpec_RanksCandidates(t *testing.T) {
+	// Arrange
+	mockAlgorithm := new(mocks.IAlgorithm)
+	mockCache := new(mocks.ICacheService)
+	mockMapper := new(mocks.IMapper)
+
+	service := services.NewComputeService(mockAlgorithm, mockCache, mockMapper)
+
+	pod := &corev1.Pod{}
+
+	mockMapper.On("MapPodToWeightedPod", pod).Return(ultron.WeightedPod{
+		Weights: map[string]float64{
+			ultron.WeightKeyCpuRequested:    2,
+			ultron.WeightKeyMemoryRequested: 4,
+		},
+	}, nil)
+
+	mockCache.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{
+		{Selector: map[string]string{ultron.LabelHostName: "small"}, Weights: map[string]float64{ultron.WeightKeyCpuAvailable: 1, ultron.WeightKeyMemoryAvailable: 8}},
+		{Selector: map[string]string{ultron.LabelHostName: "low"}, Weights: map[string]float64{ultron.WeightKeyCpuAvailable: 4, ultron.WeightKeyMemoryAvailable: 8}},
+		{Selector: map[string]string{ultron.LabelHostName: "high"}, Weights: map[string]float64{ultron.WeightKeyCpuAvailable: 8, ultron.WeightKeyMemoryAvailable: 16}},
+	}, nil)
+
+	mockAlgorithm.On("Explain", mock.MatchedBy(func(wNode *ultron.WeightedNode) bool { return wNode.Selector[ultron.LabelHostName] == "high" }), mock.Anything).Return(ultron.ScoreExplanation{TotalScore: 2})
+	mockAlgorithm.On("Explain", mock.Anything, mock.Anything).Return(ultron.ScoreExplanation{TotalScore: 1})
+
+	// Act
+	candidates, err := service.ExplainPodSpec(context.Background(), pod)
+
+	// Assert
+	assert.NoError(t, err, "ExplainPodSpec should not return an error")
+	assert.Len(t, candidates, 3)
+	assert.Equal(t, "high", candidates[0].Selector[ultron.LabelHostName])
+	assert.Equal(t, 1, candidates[0].Rank)
+	assert.Equal(t, "low", candidates[1].Selector[ultron.LabelHostName])
+	assert.Equal(t, 2, candidates[1].Rank)
+	assert.False(t, candidates[2].Eligible, "Expected nodes without enough cpu to be ineligible")
+	assert.Equal(t, 0, candidates[2].Rank)
+	assert.Contains(t, candidates[2].Reason, "insufficient cpu")
+}
+
+func TestRankWeightedPodToWeightedNodes_Limit(t *testing.T) {
+	// Arrange
+	mockAlgorithm := new(mocks.IAlgorithm)
+	mockCache := new(mocks.ICacheService)
+	mockMapper := new(mocks.IMapper)
+
+	service := services.NewComputeService(mockAlgorithm, mockCache, mockMapper)
+
+	wPod := ultron.WeightedPod{
+		Weights: map[string]float64{
+			ultron.WeightKeyCpuRequested:    2,
+			ultron.WeightKeyMemoryRequested: 4,
+		},
+	}
+
+	mockCache.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{
+		{Selector: map[string]string{ultron.LabelHostName: "small"}, Weights: map[string]float64{ultron.WeightKeyCpuAvailable: 1, ultron.WeightKeyMemoryAvailable: 8}},
+		{Selector: map[string]string{ultron.LabelHostName: "low"}, Weights: map[string]float64{ultron.WeightKeyCpuAvailable: 4, ultron.WeightKeyMemoryAvailable: 8}},
+		{Selector: map[string]string{ultron.LabelHostName: "mid"}, Weights: map[string]float64{ultron.WeightKeyCpuAvailable: 4, ultron.WeightKeyMemoryAvailable: 8}},
+		{Selector: map[string]string{ultron.LabelHostName: "high"}, Weights: map[string]float64{ultron.WeightKeyCpuAvailable: 8, ultron.WeightKeyMemoryAvailable: 16}},
+	}, nil)
+
+	mockAlgorithm.On("TotalScore", mock.MatchedBy(func(wNode *ultron.WeightedNode) bool { return wNode.Selector[ultron.LabelHostName] == "high" }), mock.Anything).Return(3.0)
+	mockAlgorithm.On("TotalScore", mock.MatchedBy(func(wNode *ultron.WeightedNode) bool { return wNode.Selector[ultron.LabelHostName] == "mid" }), mock.Anything).Return(2.0)
+	mockAlgorithm.On("TotalScore", mock.Anything, mock.Anything).Return(1.0)
+
+	// Act
+	rankedNodes, err := service.RankWeightedPodToWeightedNodes(context.Background(), &wPod, 2)
+
+	// Assert
+	assert.NoError(t, err, "RankWeightedPodToWeightedNodes should not return an error")
+	assert.Len(t, rankedNodes, 2, "Expected ranking to be limited")
+	assert.Equal(t, "high", rankedNodes[0].Node.Selector[ultron.LabelHostName])
+	assert.Equal(t, 3.0, rankedNodes[0].TotalScore)
+	assert.Equal(t, "mid", rankedNodes[1].Node.Selector[ultron.LabelHostName])
+}
+
+func TestValidatePodSpec_Malformed(t *testing.T) {
+	// Arrange
+	service := services.NewComputeService(new(mocks.IAlgorithm), new(mocks.ICacheService), new(mocks.IMapper))
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod1",
+			Annotations: map[string]string{
+				ultron.AnnotationDiskType:      "",
+				ultron.AnnotationStorageSizeGb: "ten",
+			},
+		},
+	}
+
+	// Act
+	violations, err := service.ValidatePodSpec(context.Background(), pod)
+
+	// Assert
+	assert.NoError(t, err, "ValidatePodSpec should not return an error")
+	assert.Len(t, violations, 2)
+	assert.True(t, violations[0].Malformed)
+	assert.Equal(t, ultron.AnnotationDiskType, violations[0].Annotation)
+	assert.Equal(t, ultron.AnnotationStorageSizeGb, violations[1].Annotation)
+}
+
+func TestValidatePodSpec_Unsatisfiable(t *testing.T) {
+	// Arrange
+	mockCache := new(mocks.ICacheService)
+	service := services.NewComputeService(new(mocks.IAlgorithm), mockCache, new(mocks.IMapper))
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod1",
+			Annotations: map[string]string{
+				ultron.AnnotationDiskType:      "NVMe",
+				ultron.AnnotationStorageSizeGb: "50",
+			},
+		},
+	}
+
+	mockCache.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{
+		{Annotations: map[string]string{ultron.AnnotationDiskType: "SSD"}, Weights: map[string]float64{ultron.WeightKeyStorageAvailable: 100}},
+	}, nil)
+	mockCache.On("GetAllComputeConfigurations", mock.Anything).Return(nil, services.ErrCacheKeyNotFound)
+
+	// Act
+	violations, err := service.ValidatePodSpec(context.Background(), pod)
+
+	// Assert
+	assert.NoError(t, err, "ValidatePodSpec should not return an error")
+	assert.Len(t, violations, 1)
+	assert.False(t, violations[0].Malformed)
+	assert.Equal(t, ultron.AnnotationDiskType, violations[0].Annotation)
+	assert.Equal(t, "NVMe", violations[0].Value)
+}
+
+func TestValidatePodSpec_CombinedUnsatisfiable(t *testing.T) {
+	// Arrange
+	mockCache := new(mocks.ICacheService)
+	service := services.NewComputeService(new(mocks.IAlgorithm), mockCache, new(mocks.IMapper))
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pod1",
+			Annotations: map[string]string{
+				ultron.AnnotationDiskType:    "SSD",
+				ultron.AnnotationNetworkType: "public",
+			},
+		},
+	}
+
+	mockCache.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{
+		{Annotations: map[string]string{ultron.AnnotationDiskType: "SSD", ultron.AnnotationNetworkType: "isolated"}},
+	}, nil)
+	mockCache.On("GetAllComputeConfigurations", mock.Anything).Return([]ultron.ComputeConfiguration{
+		{VolumeType: stringPtr("HDD"), CloudNetworkTypes: []string{"public"}},
+	}, nil)
+
+	// Act
+	violations, err := service.ValidatePodSpec(context.Background(), pod)
+
+	// Assert
+	assert.NoError(t, err, "ValidatePodSpec should not return an error")
+	assert.Len(t, violations, 1)
+	assert.Empty(t, violations[0].Annotation, "Expected a combined violation")
+}
+
+func TestValidatePodSpec_Satisfiable(t *testing.T) {
+	// Arrange
+	mockCache := new(mocks.ICacheService)
+	service := services.NewComputeService(new(mocks.IAlgorithm), mockCache, new(mocks.IMapper))
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pod1",
+			Annotations: map[string]string{ultron.AnnotationDiskType: "SSD"},
+		},
+	}
+
+	mockCache.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{}, nil)
+	mockCache.On("GetAllComputeConfigurations", mock.Anything).Return([]ultron.ComputeConfiguration{
+		{VolumeType: stringPtr("SSD")},
+	}, nil)
+
+	// Act
+	violations, err := service.ValidatePodSpec(context.Background(), pod)
+
+	// Assert
+	assert.NoError(t, err, "ValidatePodSpec should not return an error")
+	assert.Empty(t, violations)
+}
+
+func TestRankWeightedPodToWeightedNodes_Cancelled(t *testing.T) {
+	// Arrange
+	mockAlgorithm := new(mocks.IAlgorithm)
+	mockCache := new(mocks.ICacheService)
+	mockMapper := new(mocks.IMapper)
+
+	service := services.NewComputeService(mockAlgorithm, mockCache, mockMapper)
+
+	mockCache.On("GetWeightedNodes", mock.Anything).Return([]ultron.WeightedNode{
+		{Selector: map[string]string{ultron.LabelHostName: "node1"}},
+	}, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Act
+	rankedNodes, err := service.RankWeightedPodToWeightedNodes(ctx, &ultron.WeightedPod{}, 0)
+
+	// Assert
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, rankedNodes, "Expected no ranked nodes once the context is cancelled")
+	mockAlgorithm.AssertNotCalled(t, "TotalScore", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+const (
+	certificateAuthorityCertFile = "ca.crt"
+	certificateAuthorityKeyFile  = "ca.key"
+)
+
+type FileCertificateAuthorityStore struct {
+	directory string
+}
+
+func NewFileCertificateAuthorityStore(directory string) *FileCertificateAuthorityStore {
+	return &FileCertificateAuthorityStore{directory: directory}
+}
+
+func (s *FileCertificateAuthorityStore) Load(ctx context.Context) (*CertificateAuthority, error) {
+	certPEM, err := os.ReadFile(filepath.Join(s.directory, certificateAuthorityCertFile))
+	if err != nil {
+		return nil, fileCertificateAuthorityError(err)
+	}
+
+	keyPEM, err := os.ReadFile(filepath.Join(s.directory, certificateAuthorityKeyFile))
+	if err != nil {
+		return nil, fileCertificateAuthorityError(err)
+	}
+
+	return DecodeCertificateAuthority(certPEM, keyPEM)
+}
+
+func (s *FileCertificateAuthorityStore) Save(ctx context.Context, authority *CertificateAuthority) error {
+	certPEM, keyPEM, err := EncodeCertificateAuthority(authority)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.directory, 0700); err != nil {
+		return fmt.Errorf("failed to create certificate authority directory: %w", err)
+	}
+
+	if err := writeFileAtomic(filepath.Join(s.directory, certificateAuthorityKeyFile), keyPEM, 0600); err != nil {
+		return fmt.Errorf("failed to write CA private key: %w", err)
+	}
+
+	if err := writeFileAtomic(filepath.Join(s.directory, certificateAuthorityCertFile), certPEM, 0644); err != nil {
+		return fmt.Errorf("failed to write CA certificate: %w", err)
+	}
+
+	return nil
+}
+
+func fileCertificateAuthorityError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrCertificateAuthorityNotFound
+	}
+
+	return fmt.Errorf("failed to read certificate authority: %w", err)
+}
+
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+
+	"github.com/fsnotify/fsnotify"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const certificateReloadDelay = 250 * time.Millisecond
+
+// FileCertificateSource serves tls.crt and tls.key from a directory, such as a mounted cert-manager
+// Secret, and publishes ca.crt as the CA bundle when present.
+type FileCertificateSource struct {
+	certificateHolder
+	directory string
+}
+
+func NewFileCertificateSource(directory string) *FileCertificateSource {
+	return &FileCertificateSource{directory: directory}
+}
+
+func (s *FileCertificateSource) Rotate(ctx context.Context) error {
+	certificate, caBundle, err := s.load()
+	if err != nil {
+		metrics.CertificateRotationsTotal.WithLabelValues(metrics.RotationResultError).Inc()
+
+		return err
+	}
+
+	return s.swap(ctx, certificate, caBundle)
+}
+
+// Start watches the directory rather than the files, because mounted Secrets are updated by
+// swapping a symlink, and reloads once shortly after the first event of each burst.
+func (s *FileCertificateSource) Start(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create certificate watcher: %w", err)
+	}
+
+	defer watcher.Close()
+
+	if err := watcher.Add(s.directory); err != nil {
+		return fmt.Errorf("failed to watch certificate directory %s: %w", s.directory, err)
+	}
+
+	reload := time.NewTimer(certificateReloadDelay)
+	reload.Stop()
+
+	reloadPending := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			reload.Stop()
+
+			return ctx.Err()
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if !reloadPending {
+				reloadPending = true
+				reload.Reset(certificateReloadDelay)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+			log.Printf("Could not watch certificate directory %s: %v", s.directory, err)
+		case <-reload.C:
+			reloadPending = false
+
+			if err := s.Rotate(ctx); err != nil {
+				log.Printf("Could not reload certificate from %s: %v", s.directory, err)
+			}
+		}
+	}
+}
+
+func (s *FileCertificateSource) load() (*tls.Certificate, []byte, error) {
+	certPEM, err := os.ReadFile(filepath.Join(s.directory, corev1.TLSCertKey))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read certificate: %w", err)
+	}
+
+	keyPEM, err := os.ReadFile(filepath.Join(s.directory, corev1.TLSPrivateKeyKey))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read private key: %w", err)
+	}
+
+	certificate, err := parseServingCertificate(certPEM, keyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	caBundle, err := os.ReadFile(filepath.Join(s.directory, certificateAuthorityCertFile))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("failed to read CA bundle: %w", err)
+	}
+
+	return certificate, caBundle, nil
+}
@@ -0,0 +1,130 @@
+package services_test
+
+import (
+	"context"
+	"crypto/tls"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	services "github.com/be-heroes/ultron/pkg/services"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeCertificateFiles(t *testing.T, directory string, certPEM []byte, keyPEM []byte, caPEM []byte) {
+	t.Helper()
+
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "tls.crt"), certPEM, 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "tls.key"), keyPEM, 0600))
+
+	if caPEM != nil {
+		assert.NoError(t, os.WriteFile(filepath.Join(directory, "ca.crt"), caPEM, 0644))
+	}
+}
+
+func TestFileCertificateSource_Rotate(t *testing.T) {
+	// Arrange
+	directory := t.TempDir()
+	certPEM, keyPEM, caPEM := newServingCertificatePem(t)
+	writeCertificateFiles(t, directory, certPEM, keyPEM, caPEM)
+
+	source := services.NewFileCertificateSource(directory)
+
+	var caBundles [][]byte
+	source.AddRotationHandler(func(ctx context.Context, caBundle []byte) error {
+		caBundles = append(caBundles, caBundle)
+
+		return nil
+	})
+
+	// Act
+	err := source.Rotate(context.Background())
+	certificate, certificateErr := source.GetCertificate(&tls.ClientHelloInfo{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, certificateErr)
+	assert.Equal(t, "test.com", certificate.Leaf.Subject.CommonName)
+	assert.Equal(t, [][]byte{caPEM}, caBundles, "Expected ca.crt to be published as the CA bundle")
+}
+
+func TestFileCertificateSource_RotateMissingFiles(t *testing.T) {
+	// Arrange
+	source := services.NewFileCertificateSource(t.TempDir())
+
+	// Act
+	err := source.Rotate(context.Background())
+	_, certificateErr := source.Certificate()
+
+	// Assert
+	assert.Error(t, err, "Expected an error when no key pair exists")
+	assert.Error(t, certificateErr, "Expected no certificate to be served")
+}
+
+func TestFileCertificateSource_RotateWithoutCaBundle(t *testing.T) {
+	// Arrange
+	directory := t.TempDir()
+	certPEM, keyPEM, _ := newServingCertificatePem(t)
+	writeCertificateFiles(t, directory, certPEM, keyPEM, nil)
+
+	source := services.NewFileCertificateSource(directory)
+
+	handlerCalled := false
+	source.AddRotationHandler(func(ctx context.Context, caBundle []byte) error {
+		handlerCalled = true
+
+		return nil
+	})
+
+	// Act
+	err := source.Rotate(context.Background())
+	_, certificateErr := source.Certificate()
+
+	// Assert
+	assert.Error(t, err, "Expected an error when rotation handlers need a CA bundle")
+	assert.NoError(t, certificateErr, "Expected the certificate to be served regardless")
+	assert.False(t, handlerCalled, "Expected no rotation handler without a CA bundle")
+}
+
+func TestFileCertificateSource_RotateWithoutCaBundleOrHandlers(t *testing.T) {
+	// Arrange
+	directory := t.TempDir()
+	certPEM, keyPEM, _ := newServingCertificatePem(t)
+	writeCertificateFiles(t, directory, certPEM, keyPEM, nil)
+
+	source := services.NewFileCertificateSource(directory)
+
+	// Act
+	err := source.Rotate(context.Background())
+
+	// Assert
+	assert.NoError(t, err, "Expected a CA bundle to be optional without rotation handlers")
+}
+
+func TestFileCertificateSource_StartReloadsOnChange(t *testing.T) {
+	// Arrange
+	directory := t.TempDir()
+	certPEM, keyPEM, caPEM := newServingCertificatePem(t)
+	writeCertificateFiles(t, directory, certPEM, keyPEM, caPEM)
+
+	source := services.NewFileCertificateSource(directory)
+	_ = source.Rotate(context.Background())
+	initial, _ := source.Certificate()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go source.Start(ctx)
+
+	// Act
+	rotatedCertPEM, rotatedKeyPEM, rotatedCaPEM := newServingCertificatePem(t)
+
+	assert.Eventually(t, func() bool {
+		writeCertificateFiles(t, directory, rotatedCertPEM, rotatedKeyPEM, rotatedCaPEM)
+
+		current, _ := source.Certificate()
+
+		return current.Leaf.SerialNumber.Cmp(initial.Leaf.SerialNumber) != 0
+	}, 5*time.Second, 100*time.Millisecond, "Expected the rotated certificate to be served")
+}
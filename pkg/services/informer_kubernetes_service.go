@@ -0,0 +1,267 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	k8s "github.com/be-heroes/ultron/internal/clients/kubernetes"
+	ultron "github.com/be-heroes/ultron/pkg"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	metricsclient "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+type IInformerKubernetesService interface {
+	IKubernetesService
+	Start(ctx context.Context) error
+	AddEventHandler(handler func(event ultron.ClusterEvent))
+}
+
+type InformerKubernetesService struct {
+	informerFactory   informers.SharedInformerFactory
+	nodeInformer      cache.SharedIndexInformer
+	podInformer       cache.SharedIndexInformer
+	namespaceInformer cache.SharedIndexInformer
+	nodeLister        corelisters.NodeLister
+	podLister         corelisters.PodLister
+	namespaceLister   corelisters.NamespaceLister
+	metricsClient     IMetricsClient
+	metricsInterval   time.Duration
+	metricsMutex      sync.RWMutex
+	nodeMetrics       map[string]map[string]string
+	podMetrics        map[string]map[string]string
+	handlersMutex     sync.RWMutex
+	handlers          []func(event ultron.ClusterEvent)
+}
+
+func NewInformerKubernetesService(clientSet kubernetes.Interface, metricsClient IMetricsClient, resyncPeriod time.Duration, metricsInterval time.Duration) *InformerKubernetesService {
+	informerFactory := informers.NewSharedInformerFactory(clientSet, resyncPeriod)
+
+	ks := &InformerKubernetesService{
+		informerFactory:   informerFactory,
+		nodeInformer:      informerFactory.Core().V1().Nodes().Informer(),
+		podInformer:       informerFactory.Core().V1().Pods().Informer(),
+		namespaceInformer: informerFactory.Core().V1().Namespaces().Informer(),
+		nodeLister:        informerFactory.Core().V1().Nodes().Lister(),
+		podLister:         informerFactory.Core().V1().Pods().Lister(),
+		namespaceLister:   informerFactory.Core().V1().Namespaces().Lister(),
+		metricsClient:     metricsClient,
+		metricsInterval:   metricsInterval,
+		nodeMetrics:       make(map[string]map[string]string),
+		podMetrics:        make(map[string]map[string]string),
+	}
+
+	ks.nodeInformer.AddEventHandler(ks.newResourceEventHandler(ultron.ClusterEventKindNode))
+	ks.podInformer.AddEventHandler(ks.newResourceEventHandler(ultron.ClusterEventKindPod))
+	ks.namespaceInformer.AddEventHandler(ks.newResourceEventHandler(ultron.ClusterEventKindNamespace))
+
+	return ks
+}
+
+func NewInformerKubernetesServiceFromConfig(config *rest.Config, resyncPeriod time.Duration, metricsInterval time.Duration) (*InformerKubernetesService, error) {
+	k8sClientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	metricsClientset, err := metricsclient.NewForConfig(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewInformerKubernetesService(k8sClientset, &k8s.RealMetricsClient{ClientSet: metricsClientset}, resyncPeriod, metricsInterval), nil
+}
+
+func (ks *InformerKubernetesService) Start(ctx context.Context) error {
+	ks.informerFactory.Start(ctx.Done())
+
+	for informerType, synced := range ks.informerFactory.WaitForCacheSync(ctx.Done()) {
+		if !synced {
+			return fmt.Errorf("failed to sync informer cache for %v", informerType)
+		}
+	}
+
+	if ks.metricsClient != nil {
+		if err := ks.pollMetrics(ctx); err != nil {
+			log.Printf("Could not poll metrics: %v", err)
+		}
+
+		go ks.runMetricsPoller(ctx)
+	}
+
+	return nil
+}
+
+func (ks *InformerKubernetesService) AddEventHandler(handler func(event ultron.ClusterEvent)) {
+	ks.handlersMutex.Lock()
+	defer ks.handlersMutex.Unlock()
+
+	ks.handlers = append(ks.handlers, handler)
+}
+
+func (ks *InformerKubernetesService) GetNodes(ctx context.Context, options metav1.ListOptions) ([]corev1.Node, error) {
+	selector, err := labels.Parse(options.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	nodes, err := ks.nodeLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []corev1.Node
+
+	for _, node := range nodes {
+		result = append(result, *node.DeepCopy())
+	}
+
+	return result, nil
+}
+
+func (ks *InformerKubernetesService) GetPods(ctx context.Context, options metav1.ListOptions) ([]corev1.Pod, error) {
+	selector, err := labels.Parse(options.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := ks.podLister.List(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []corev1.Pod
+
+	for _, pod := range pods {
+		result = append(result, *pod.DeepCopy())
+	}
+
+	return result, nil
+}
+
+func (ks *InformerKubernetesService) GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	namespace, err := ks.namespaceLister.Get(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return namespace.DeepCopy(), nil
+}
+
+func (ks *InformerKubernetesService) GetNodeMetrics(ctx context.Context, options metav1.ListOptions) (map[string]map[string]string, error) {
+	ks.metricsMutex.RLock()
+	defer ks.metricsMutex.RUnlock()
+
+	return copyMetrics(ks.nodeMetrics), nil
+}
+
+func (ks *InformerKubernetesService) GetPodMetrics(ctx context.Context, options metav1.ListOptions) (map[string]map[string]string, error) {
+	ks.metricsMutex.RLock()
+	defer ks.metricsMutex.RUnlock()
+
+	return copyMetrics(ks.podMetrics), nil
+}
+
+func (ks *InformerKubernetesService) runMetricsPoller(ctx context.Context) {
+	ticker := time.NewTicker(ks.metricsInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := ks.pollMetrics(ctx); err != nil {
+				log.Printf("Could not poll metrics: %v", err)
+			}
+		}
+	}
+}
+
+func (ks *InformerKubernetesService) pollMetrics(ctx context.Context) error {
+	metricsNodeList, err := ks.metricsClient.ListNodeMetrics(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	metricsPodList, err := ks.metricsClient.ListPodMetrics(ctx, metav1.NamespaceAll, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	nodeMetrics := mapNodeMetrics(metricsNodeList)
+	podMetrics := mapPodMetrics(metricsPodList)
+
+	ks.metricsMutex.Lock()
+	defer ks.metricsMutex.Unlock()
+
+	ks.nodeMetrics = nodeMetrics
+	ks.podMetrics = podMetrics
+
+	return nil
+}
+
+func (ks *InformerKubernetesService) newResourceEventHandler(kind string) cache.ResourceEventHandler {
+	return cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			ks.emit(ultron.ClusterEvent{Type: ultron.ClusterEventTypeAdded, Kind: kind, Object: toRuntimeObject(obj)})
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			ks.emit(ultron.ClusterEvent{Type: ultron.ClusterEventTypeUpdated, Kind: kind, Object: toRuntimeObject(newObj), OldObject: toRuntimeObject(oldObj)})
+		},
+		DeleteFunc: func(obj interface{}) {
+			ks.emit(ultron.ClusterEvent{Type: ultron.ClusterEventTypeDeleted, Kind: kind, Object: toRuntimeObject(obj)})
+		},
+	}
+}
+
+func (ks *InformerKubernetesService) emit(event ultron.ClusterEvent) {
+	if event.Object == nil {
+		return
+	}
+
+	ks.handlersMutex.RLock()
+	defer ks.handlersMutex.RUnlock()
+
+	for _, handler := range ks.handlers {
+		handler(event)
+	}
+}
+
+func toRuntimeObject(obj interface{}) runtime.Object {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+
+	runtimeObject, ok := obj.(runtime.Object)
+	if !ok {
+		return nil
+	}
+
+	return runtimeObject
+}
+
+func copyMetrics(metrics map[string]map[string]string) map[string]map[string]string {
+	result := make(map[string]map[string]string, len(metrics))
+
+	for key, values := range metrics {
+		result[key] = make(map[string]string, len(values))
+
+		for valueKey, value := range values {
+			result[key][valueKey] = value
+		}
+	}
+
+	return result
+}
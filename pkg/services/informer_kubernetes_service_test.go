@@ -0,0 +1,147 @@
+package services_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/be-heroes/ultron/mocks"
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestInformerGetNodesAndPods(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientSet := fake.NewSimpleClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1", Labels: map[string]string{"pool": "a"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node2", Labels: map[string]string{"pool": "b"}}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod1", Namespace: "default"}},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "pod2", Namespace: "kube-system"}},
+	)
+
+	service := services.NewInformerKubernetesService(clientSet, nil, 0, time.Minute)
+
+	// Act
+	err := service.Start(ctx)
+	nodes, nodesErr := service.GetNodes(ctx, metav1.ListOptions{LabelSelector: "pool=a"})
+	pods, podsErr := service.GetPods(ctx, metav1.ListOptions{})
+
+	// Assert
+	assert.NoError(t, err, "Start should not return an error")
+	assert.NoError(t, nodesErr, "GetNodes should not return an error")
+	assert.NoError(t, podsErr, "GetPods should not return an error")
+	assert.Len(t, nodes, 1, "Expected label selector to filter nodes")
+	assert.Equal(t, "node1", nodes[0].Name)
+	assert.Len(t, pods, 2, "Expected pods from all namespaces")
+}
+
+func TestInformerGetMetrics(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockMetricsClient := new(mocks.IMetricsClient)
+	mockMetricsClient.On("ListNodeMetrics", mock.Anything, metav1.ListOptions{}).Return(&ultron.MetricsNodeList{
+		Items: []ultron.MetricsNode{
+			{
+				Name: "node1",
+				Usage: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		},
+	}, nil)
+	mockMetricsClient.On("ListPodMetrics", mock.Anything, metav1.NamespaceAll, metav1.ListOptions{}).Return(&ultron.MetricsPodList{
+		Items: []ultron.MetricsPod{
+			{
+				Name:      "pod1",
+				Namespace: "default",
+				Containers: []ultron.MetricsContainer{
+					{
+						Name: "container1",
+						Usage: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse("100m"),
+							corev1.ResourceMemory: resource.MustParse("128Mi"),
+						},
+					},
+				},
+			},
+		},
+	}, nil)
+
+	service := services.NewInformerKubernetesService(fake.NewSimpleClientset(), mockMetricsClient, 0, time.Minute)
+
+	// Act
+	err := service.Start(ctx)
+	nodeMetrics, _ := service.GetNodeMetrics(ctx, metav1.ListOptions{})
+	podMetrics, _ := service.GetPodMetrics(ctx, metav1.ListOptions{})
+
+	// Assert
+	assert.NoError(t, err, "Start should not return an error")
+	assert.Equal(t, "0.500", nodeMetrics["node1"][ultron.WeightKeyCpuUsage])
+	assert.Equal(t, "1073741824", nodeMetrics["node1"][ultron.WeightKeyMemoryUsage])
+	assert.Equal(t, "100", podMetrics["default/pod1"][ultron.WeightKeyCpuTotal])
+	assert.Equal(t, "134217728", podMetrics["default/pod1"][ultron.WeightKeyMemoryTotal])
+}
+
+func TestInformerEmitsNodeEvents(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientSet := fake.NewSimpleClientset()
+	service := services.NewInformerKubernetesService(clientSet, nil, 0, time.Minute)
+
+	var mutex sync.Mutex
+	var events []ultron.ClusterEvent
+
+	service.AddEventHandler(func(event ultron.ClusterEvent) {
+		if event.Kind != ultron.ClusterEventKindNode {
+			return
+		}
+
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		events = append(events, event)
+	})
+
+	// Act
+	err := service.Start(ctx)
+	assert.NoError(t, err, "Start should not return an error")
+
+	node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node1"}}
+	_, _ = clientSet.CoreV1().Nodes().Create(ctx, node, metav1.CreateOptions{})
+	node.Labels = map[string]string{"pool": "a"}
+	_, _ = clientSet.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+	_ = clientSet.CoreV1().Nodes().Delete(ctx, node.Name, metav1.DeleteOptions{})
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		return len(events) == 3
+	}, 5*time.Second, 10*time.Millisecond, "Expected add, update and delete events")
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	assert.Equal(t, ultron.ClusterEventTypeAdded, events[0].Type)
+	assert.Equal(t, ultron.ClusterEventTypeUpdated, events[1].Type)
+	assert.NotNil(t, events[1].OldObject, "Expected update events to carry the previous object")
+	assert.Equal(t, ultron.ClusterEventTypeDeleted, events[2].Type)
+}
@@ -19,6 +19,7 @@ type ICoreClient interface {
 	ListPods(ctx context.Context, namespace string, opts metav1.ListOptions) (*corev1.PodList, error)
 	ListNodes(ctx context.Context, opts metav1.ListOptions) (*corev1.NodeList, error)
 	ListNamespaces(ctx context.Context, opts metav1.ListOptions) (*corev1.NamespaceList, error)
+	GetNamespace(ctx context.Context, name string, opts metav1.GetOptions) (*corev1.Namespace, error)
 }
 
 type IMetricsClient interface {
@@ -29,6 +30,7 @@ type IMetricsClient interface {
 type IKubernetesService interface {
 	GetPods(ctx context.Context, options metav1.ListOptions) ([]corev1.Pod, error)
 	GetNodes(ctx context.Context, options metav1.ListOptions) ([]corev1.Node, error)
+	GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error)
 	GetNodeMetrics(ctx context.Context, options metav1.ListOptions) (map[string]map[string]string, error)
 	GetPodMetrics(ctx context.Context, options metav1.ListOptions) (map[string]map[string]string, error)
 }
@@ -39,7 +41,7 @@ type KubernetesService struct {
 	MetricsClient IMetricsClient
 }
 
-func NewKubernetesService(kubernetesMasterUrl string, kubernetesConfigPath string, insecure bool) (*KubernetesService, error) {
+func NewKubernetesConfig(kubernetesMasterUrl string, kubernetesConfigPath string, insecure bool) (*rest.Config, error) {
 	if kubernetesMasterUrl == "https://:" {
 		kubernetesMasterUrl = ""
 	}
@@ -51,6 +53,15 @@ func NewKubernetesService(kubernetesMasterUrl string, kubernetesConfigPath strin
 
 	config.Insecure = insecure
 
+	return config, nil
+}
+
+func NewKubernetesService(kubernetesMasterUrl string, kubernetesConfigPath string, insecure bool) (*KubernetesService, error) {
+	config, err := NewKubernetesConfig(kubernetesMasterUrl, kubernetesConfigPath, insecure)
+	if err != nil {
+		return nil, err
+	}
+
 	k8sClientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return nil, err
@@ -77,6 +88,10 @@ func (ks *KubernetesService) GetNodes(ctx context.Context, options metav1.ListOp
 	return nodesList.Items, nil
 }
 
+func (ks *KubernetesService) GetNamespace(ctx context.Context, name string) (*corev1.Namespace, error) {
+	return ks.K8sClient.GetNamespace(ctx, name, metav1.GetOptions{})
+}
+
 func (ks *KubernetesService) GetPods(ctx context.Context, options metav1.ListOptions) ([]corev1.Pod, error) {
 	namespacesList, err := ks.K8sClient.ListNamespaces(ctx, options)
 	if err != nil {
@@ -103,19 +118,7 @@ func (ks *KubernetesService) GetNodeMetrics(ctx context.Context, options metav1.
 		return nil, err
 	}
 
-	metrics := make(map[string]map[string]string)
-
-	for _, nodeMetric := range metricsNodeList.Items {
-		cpuUsage := nodeMetric.Usage["cpu"]
-		memoryUsage := nodeMetric.Usage["memory"]
-
-		metrics[nodeMetric.Name] = map[string]string{
-			ultron.WeightKeyCpuUsage:    cpuUsage.AsDec().String(),
-			ultron.WeightKeyMemoryUsage: memoryUsage.AsDec().String(),
-		}
-	}
-
-	return metrics, nil
+	return mapNodeMetrics(metricsNodeList), nil
 }
 
 func (ks *KubernetesService) GetPodMetrics(ctx context.Context, options metav1.ListOptions) (map[string]map[string]string, error) {
@@ -132,25 +135,51 @@ func (ks *KubernetesService) GetPodMetrics(ctx context.Context, options metav1.L
 			return nil, err
 		}
 
-		for _, podMetric := range podMetricsList.Items {
-			cpuTotal := int64(0)
-			memoryTotal := int64(0)
+		for key, value := range mapPodMetrics(podMetricsList) {
+			metrics[key] = value
+		}
+	}
+
+	return metrics, nil
+}
 
-			for _, container := range podMetric.Containers {
-				cpuUsage := container.Usage[corev1.ResourceCPU]
-				memUsage := container.Usage[corev1.ResourceMemory]
+func mapNodeMetrics(metricsNodeList *ultron.MetricsNodeList) map[string]map[string]string {
+	metrics := make(map[string]map[string]string)
 
-				cpuTotal += cpuUsage.MilliValue()
-				memoryTotal += memUsage.Value()
-			}
+	for _, nodeMetric := range metricsNodeList.Items {
+		cpuUsage := nodeMetric.Usage["cpu"]
+		memoryUsage := nodeMetric.Usage["memory"]
 
-			metricsKey := fmt.Sprintf("%s/%s", podMetric.Namespace, podMetric.Name)
-			metrics[metricsKey] = map[string]string{
-				ultron.WeightKeyCpuTotal:    strconv.FormatInt(cpuTotal, 10),
-				ultron.WeightKeyMemoryTotal: strconv.FormatInt(memoryTotal, 10),
-			}
+		metrics[nodeMetric.Name] = map[string]string{
+			ultron.WeightKeyCpuUsage:    cpuUsage.AsDec().String(),
+			ultron.WeightKeyMemoryUsage: memoryUsage.AsDec().String(),
 		}
 	}
 
-	return metrics, nil
+	return metrics
+}
+
+func mapPodMetrics(metricsPodList *ultron.MetricsPodList) map[string]map[string]string {
+	metrics := make(map[string]map[string]string)
+
+	for _, podMetric := range metricsPodList.Items {
+		cpuTotal := int64(0)
+		memoryTotal := int64(0)
+
+		for _, container := range podMetric.Containers {
+			cpuUsage := container.Usage[corev1.ResourceCPU]
+			memUsage := container.Usage[corev1.ResourceMemory]
+
+			cpuTotal += cpuUsage.MilliValue()
+			memoryTotal += memUsage.Value()
+		}
+
+		metricsKey := fmt.Sprintf("%s/%s", podMetric.Namespace, podMetric.Name)
+		metrics[metricsKey] = map[string]string{
+			ultron.WeightKeyCpuTotal:    strconv.FormatInt(cpuTotal, 10),
+			ultron.WeightKeyMemoryTotal: strconv.FormatInt(memoryTotal, 10),
+		}
+	}
+
+	return metrics
 }
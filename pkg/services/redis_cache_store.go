@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	"github.com/redis/go-redis/v9"
+)
+
+var ErrCacheKeyNotFound = errors.New("key not found")
+
+type IRedisCacheStore interface {
+	Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error
+	Get(ctx context.Context, key string) (interface{}, error)
+	GetWithTtl(ctx context.Context, key string) (interface{}, time.Duration, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type RedisCacheStore struct {
+	redisClient   *redis.Client
+	mutex         sync.RWMutex
+	registrations map[string]redisCacheRegistration
+}
+
+type redisCacheRegistration struct {
+	codec  ICacheCodec
+	decode func(data []byte) (interface{}, error)
+}
+
+func NewRedisCacheStore(redisClient *redis.Client) *RedisCacheStore {
+	store := &RedisCacheStore{
+		redisClient:   redisClient,
+		registrations: make(map[string]redisCacheRegistration),
+	}
+
+	RegisterCacheKey[[]ultron.WeightedNode](store, ultron.CacheKeyWeightedNodes, &GobCacheCodec{})
+	RegisterCacheKey[[]ultron.ComputeConfiguration](store, ultron.CacheKeyDurableComputeConfigurations, &JsonCacheCodec{})
+	RegisterCacheKey[[]ultron.ComputeConfiguration](store, ultron.CacheKeyEphemeralComputeConfigurations, &JsonCacheCodec{})
+	RegisterCacheKey[[]ultron.WeightedInteruptionRate](store, ultron.CacheKeyEphemeralComputeConfigurationInteruptionRates, &GobCacheCodec{})
+	RegisterCacheKey[[]ultron.WeightedLatencyRate](store, ultron.CacheKeyDurableComputeConfigurationLatencyRates, &GobCacheCodec{})
+
+	return store
+}
+
+func RegisterCacheKey[T any](store *RedisCacheStore, key string, codec ICacheCodec) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+
+	store.registrations[key] = redisCacheRegistration{
+		codec: codec,
+		decode: func(data []byte) (interface{}, error) {
+			var value T
+
+			if err := codec.Unmarshal(data, &value); err != nil {
+				return nil, err
+			}
+
+			return value, nil
+		},
+	}
+}
+
+func (s *RedisCacheStore) Set(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	registration, err := s.registration(key)
+	if err != nil {
+		return err
+	}
+
+	data, err := registration.codec.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode cache item %s: %w", key, err)
+	}
+
+	if ttl < 0 {
+		ttl = 0
+	}
+
+	return s.redisClient.Set(ctx, key, data, ttl).Err()
+}
+
+func (s *RedisCacheStore) Get(ctx context.Context, key string) (interface{}, error) {
+	value, _, err := s.GetWithTtl(ctx, key)
+
+	return value, err
+}
+
+func (s *RedisCacheStore) GetWithTtl(ctx context.Context, key string) (interface{}, time.Duration, error) {
+	registration, err := s.registration(key)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var getCmd *redis.StringCmd
+	var ttlCmd *redis.DurationCmd
+
+	_, err = s.redisClient.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		getCmd = pipe.Get(ctx, key)
+		ttlCmd = pipe.PTTL(ctx, key)
+
+		return nil
+	})
+	if errors.Is(err, redis.Nil) {
+		return nil, 0, ErrCacheKeyNotFound
+	} else if err != nil {
+		return nil, 0, err
+	}
+
+	data, err := getCmd.Bytes()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	value, err := registration.decode(data)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode cache item %s: %w", key, err)
+	}
+
+	return value, ttlCmd.Val(), nil
+}
+
+func (s *RedisCacheStore) Delete(ctx context.Context, key string) error {
+	return s.redisClient.Del(ctx, key).Err()
+}
+
+func (s *RedisCacheStore) registration(key string) (redisCacheRegistration, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	registration, found := s.registrations[key]
+	if !found {
+		return redisCacheRegistration{}, fmt.Errorf("no codec registered for cache key: %s", key)
+	}
+
+	return registration, nil
+}
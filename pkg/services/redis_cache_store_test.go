@@ -0,0 +1,113 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newTestRedisClient(t *testing.T) (*miniredis.Miniredis, *redis.Client) {
+	server := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+
+	t.Cleanup(func() { redisClient.Close() })
+
+	return server, redisClient
+}
+
+func TestRedisCacheStore_WeightedNodesRoundTrip(t *testing.T) {
+	// Arrange
+	_, redisClient := newTestRedisClient(t)
+	store := services.NewRedisCacheStore(redisClient)
+
+	wNodes := []ultron.WeightedNode{
+		{
+			Selector:         map[string]string{ultron.LabelHostName: "node1"},
+			Weights:          map[string]float64{ultron.WeightKeyCpuAvailable: 4},
+			InterruptionRate: ultron.WeightedInteruptionRate{Weight: 0.1},
+		},
+	}
+
+	// Act
+	err := store.Set(context.Background(), ultron.CacheKeyWeightedNodes, wNodes, 0)
+	value, getErr := store.Get(context.Background(), ultron.CacheKeyWeightedNodes)
+
+	// Assert
+	assert.NoError(t, err, "Set should not return an error")
+	assert.NoError(t, getErr, "Get should not return an error")
+	assert.Equal(t, wNodes, value, "Expected weighted nodes to round trip")
+}
+
+func TestRedisCacheStore_HonorsTtl(t *testing.T) {
+	// Arrange
+	server, redisClient := newTestRedisClient(t)
+	store := services.NewRedisCacheStore(redisClient)
+
+	// Act
+	err := store.Set(context.Background(), ultron.CacheKeyWeightedNodes, []ultron.WeightedNode{}, time.Minute)
+	server.FastForward(2 * time.Minute)
+	_, getErr := store.Get(context.Background(), ultron.CacheKeyWeightedNodes)
+
+	// Assert
+	assert.NoError(t, err, "Set should not return an error")
+	assert.ErrorIs(t, getErr, services.ErrCacheKeyNotFound, "Expected the item to expire")
+}
+
+func TestRedisCacheStore_UnregisteredKey(t *testing.T) {
+	// Arrange
+	_, redisClient := newTestRedisClient(t)
+	store := services.NewRedisCacheStore(redisClient)
+
+	// Act
+	err := store.Set(context.Background(), "ULTRON_UNKNOWN", "value", 0)
+
+	// Assert
+	assert.Error(t, err, "Expected an error for a key without a registered codec")
+}
+
+func TestRedisCacheStore_ProtobufCodec(t *testing.T) {
+	// Arrange
+	_, redisClient := newTestRedisClient(t)
+	store := services.NewRedisCacheStore(redisClient)
+
+	services.RegisterCacheKey[*wrapperspb.StringValue](store, "ULTRON_PROTOBUF", &services.ProtobufCacheCodec{})
+
+	// Act
+	err := store.Set(context.Background(), "ULTRON_PROTOBUF", wrapperspb.String("ultron"), 0)
+	value, getErr := store.Get(context.Background(), "ULTRON_PROTOBUF")
+
+	// Assert
+	assert.NoError(t, err, "Set should not return an error")
+	assert.NoError(t, getErr, "Get should not return an error")
+	assert.Equal(t, "ultron", value.(*wrapperspb.StringValue).GetValue())
+}
+
+func TestRedisCacheService_ComputeConfigurationsRoundTrip(t *testing.T) {
+	// Arrange
+	_, redisClient := newTestRedisClient(t)
+	iCache := services.NewCacheService(nil, redisClient, 0)
+
+	computeConfigs := []ultron.ComputeConfiguration{
+		{Identifier: stringPtr("config1"), ComputeType: ultron.ComputeTypeDurable, VCpu: int64Ptr(2), Cost: &ultron.ComputeCost{PricePerUnit: float64Ptr(0.1)}},
+	}
+
+	// Act
+	err := iCache.AddCacheItem(context.Background(), ultron.CacheKeyDurableComputeConfigurations, computeConfigs, 0)
+	getComputeConfigs, getErr := iCache.GetDurableComputeConfigurations(context.Background())
+	_, missingErr := iCache.GetEphemeralComputeConfigurations(context.Background())
+
+	// Assert
+	assert.NoError(t, err, "AddCacheItem should not return an error")
+	assert.NoError(t, getErr, "GetDurableComputeConfigurations should not return an error")
+	assert.Equal(t, computeConfigs, getComputeConfigs, "Expected compute configurations to round trip")
+	assert.ErrorIs(t, missingErr, services.ErrCacheKeyNotFound)
+}
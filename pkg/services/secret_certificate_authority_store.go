@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+type SecretCertificateAuthorityStore struct {
+	clientSet       kubernetes.Interface
+	namespace       string
+	name            string
+	mutex           sync.Mutex
+	resourceVersion string
+}
+
+func NewSecretCertificateAuthorityStore(clientSet kubernetes.Interface, namespace string, name string) *SecretCertificateAuthorityStore {
+	return &SecretCertificateAuthorityStore{
+		clientSet: clientSet,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+func (s *SecretCertificateAuthorityStore) Load(ctx context.Context) (*CertificateAuthority, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	secret, err := s.clientSet.CoreV1().Secrets(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		s.resourceVersion = ""
+
+		return nil, ErrCertificateAuthorityNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get secret %s/%s: %w", s.namespace, s.name, err)
+	}
+
+	s.resourceVersion = secret.ResourceVersion
+
+	if len(secret.Data[corev1.TLSCertKey]) == 0 || len(secret.Data[corev1.TLSPrivateKeyKey]) == 0 {
+		return nil, ErrCertificateAuthorityNotFound
+	}
+
+	return DecodeCertificateAuthority(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+}
+
+// Save creates the secret, or replaces it at the version last seen by Load, so replicas racing
+// to persist a new CA get ErrCertificateAuthorityConflict and can load the winner instead.
+func (s *SecretCertificateAuthorityStore) Save(ctx context.Context, authority *CertificateAuthority) error {
+	certPEM, keyPEM, err := EncodeCertificateAuthority(authority)
+	if err != nil {
+		return err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace, ResourceVersion: s.resourceVersion},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+		},
+	}
+
+	secrets := s.clientSet.CoreV1().Secrets(s.namespace)
+
+	if s.resourceVersion == "" {
+		secret, err = secrets.Create(ctx, secret, metav1.CreateOptions{})
+	} else {
+		secret, err = secrets.Update(ctx, secret, metav1.UpdateOptions{})
+	}
+
+	if apierrors.IsAlreadyExists(err) || apierrors.IsConflict(err) {
+		return ErrCertificateAuthorityConflict
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to save secret %s/%s: %w", s.namespace, s.name, err)
+	}
+
+	s.resourceVersion = secret.ResourceVersion
+
+	return nil
+}
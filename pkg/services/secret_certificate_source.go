@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	metrics "github.com/be-heroes/ultron/pkg/metrics"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// SecretCertificateSource serves the key pair of a kubernetes.io/tls Secret, such as one issued by
+// cert-manager, and publishes its ca.crt as the CA bundle when present.
+type SecretCertificateSource struct {
+	certificateHolder
+	clientSet kubernetes.Interface
+	namespace string
+	name      string
+}
+
+func NewSecretCertificateSource(clientSet kubernetes.Interface, namespace string, name string) *SecretCertificateSource {
+	return &SecretCertificateSource{
+		clientSet: clientSet,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+func (s *SecretCertificateSource) Rotate(ctx context.Context) error {
+	secret, err := s.clientSet.CoreV1().Secrets(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		metrics.CertificateRotationsTotal.WithLabelValues(metrics.RotationResultError).Inc()
+
+		return fmt.Errorf("failed to get secret %s/%s: %w", s.namespace, s.name, err)
+	}
+
+	return s.apply(ctx, secret)
+}
+
+func (s *SecretCertificateSource) Start(ctx context.Context) error {
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(s.clientSet, 0,
+		informers.WithNamespace(s.namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.FieldSelector = fields.OneTermEqualSelector("metadata.name", s.name).String()
+		}),
+	)
+
+	informer := informerFactory.Core().V1().Secrets().Informer()
+	onSecret := func(obj interface{}) {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok {
+			return
+		}
+
+		if err := s.apply(ctx, secret); err != nil {
+			log.Printf("Could not reload certificate from secret %s/%s: %v", s.namespace, s.name, err)
+		}
+	}
+
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    onSecret,
+		UpdateFunc: func(oldObj, newObj interface{}) { onSecret(newObj) },
+	}); err != nil {
+		return fmt.Errorf("failed to watch secret %s/%s: %w", s.namespace, s.name, err)
+	}
+
+	informerFactory.Start(ctx.Done())
+	defer informerFactory.Shutdown()
+
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+func (s *SecretCertificateSource) apply(ctx context.Context, secret *corev1.Secret) error {
+	certificate, err := parseServingCertificate(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey])
+	if err != nil {
+		metrics.CertificateRotationsTotal.WithLabelValues(metrics.RotationResultError).Inc()
+
+		return err
+	}
+
+	return s.swap(ctx, certificate, secret.Data[certificateAuthorityCertFile])
+}
@@ -0,0 +1,86 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	services "github.com/be-heroes/ultron/pkg/services"
+	"github.com/stretchr/testify/assert"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTlsSecret(certPEM []byte, keyPEM []byte, caPEM []byte) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ultron-tls", Namespace: "ultron"},
+		Type:       corev1.SecretTypeTLS,
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       certPEM,
+			corev1.TLSPrivateKeyKey: keyPEM,
+			"ca.crt":                caPEM,
+		},
+	}
+}
+
+func TestSecretCertificateSource_Rotate(t *testing.T) {
+	// Arrange
+	certPEM, keyPEM, caPEM := newServingCertificatePem(t)
+	source := services.NewSecretCertificateSource(fake.NewSimpleClientset(newTlsSecret(certPEM, keyPEM, caPEM)), "ultron", "ultron-tls")
+
+	var caBundles [][]byte
+	source.AddRotationHandler(func(ctx context.Context, caBundle []byte) error {
+		caBundles = append(caBundles, caBundle)
+
+		return nil
+	})
+
+	// Act
+	err := source.Rotate(context.Background())
+	certificate, certificateErr := source.Certificate()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NoError(t, certificateErr)
+	assert.Equal(t, "test.com", certificate.Leaf.Subject.CommonName)
+	assert.Equal(t, [][]byte{caPEM}, caBundles, "Expected ca.crt to be published as the CA bundle")
+}
+
+func TestSecretCertificateSource_RotateMissingSecret(t *testing.T) {
+	// Arrange
+	source := services.NewSecretCertificateSource(fake.NewSimpleClientset(), "ultron", "ultron-tls")
+
+	// Act
+	err := source.Rotate(context.Background())
+
+	// Assert
+	assert.Error(t, err, "Expected an error when the secret does not exist")
+}
+
+func TestSecretCertificateSource_StartReloadsOnUpdate(t *testing.T) {
+	// Arrange
+	certPEM, keyPEM, caPEM := newServingCertificatePem(t)
+	clientSet := fake.NewSimpleClientset(newTlsSecret(certPEM, keyPEM, caPEM))
+	source := services.NewSecretCertificateSource(clientSet, "ultron", "ultron-tls")
+	_ = source.Rotate(context.Background())
+	initial, _ := source.Certificate()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go source.Start(ctx)
+
+	// Act
+	rotatedCertPEM, rotatedKeyPEM, rotatedCaPEM := newServingCertificatePem(t)
+	_, err := clientSet.CoreV1().Secrets("ultron").Update(context.Background(), newTlsSecret(rotatedCertPEM, rotatedKeyPEM, rotatedCaPEM), metav1.UpdateOptions{})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		current, _ := source.Certificate()
+
+		return current.Leaf.SerialNumber.Cmp(initial.Leaf.SerialNumber) != 0
+	}, 5*time.Second, 50*time.Millisecond, "Expected the rotated certificate to be served")
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+)
+
+const (
+	mutatingWebhookName   = "mutate.ultron.io"
+	validatingWebhookName = "validate.ultron.io"
+
+	maxWebhookTimeoutSeconds = 30
+)
+
+type IWebhookRegistrar interface {
+	Register(ctx context.Context, caBundle []byte) error
+}
+
+type WebhookRegistrar struct {
+	clientSet         kubernetes.Interface
+	name              string
+	serviceNamespace  string
+	serviceName       string
+	servicePort       int32
+	namespaceSelector *metav1.LabelSelector
+	failurePolicy     admissionregistrationv1.FailurePolicyType
+	timeoutSeconds    int32
+}
+
+func NewWebhookRegistrar(clientSet kubernetes.Interface, config *ultron.Config) (*WebhookRegistrar, error) {
+	serviceNamespace, serviceName, found := strings.Cut(config.WebhookService, "/")
+	if !found || serviceNamespace == "" || serviceName == "" {
+		return nil, fmt.Errorf("webhook service must be in the format namespace/name: %s", config.WebhookService)
+	}
+
+	// Self-signed replicas only share a trust root through the CA store; otherwise every replica
+	// would overwrite the caBundle with its own CA and break TLS to all others.
+	if config.CertificateSource == ultron.CertificateSourceSelfSigned && config.CertificateAuthorityStore == "" {
+		return nil, fmt.Errorf("webhook registration with the %s certificate source requires a certificate authority store", config.CertificateSource)
+	}
+
+	namespaceSelector, err := metav1.ParseToLabelSelector(config.WebhookNamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse webhook namespace selector: %w", err)
+	}
+
+	// The API server only falls back to failurePolicy when Ultron cannot answer at all, so it
+	// mirrors the policy Ultron applies itself when it answers in a degraded state.
+	failurePolicy := admissionregistrationv1.Ignore
+	if config.AdmissionFailurePolicy == ultron.FailurePolicyClosed {
+		failurePolicy = admissionregistrationv1.Fail
+	}
+
+	timeoutSeconds := int32(config.AdmissionTimeout / time.Second)
+	if timeoutSeconds < 1 {
+		timeoutSeconds = 1
+	}
+
+	if timeoutSeconds > maxWebhookTimeoutSeconds {
+		timeoutSeconds = maxWebhookTimeoutSeconds
+	}
+
+	return &WebhookRegistrar{
+		clientSet:         clientSet,
+		name:              config.WebhookConfigurationName,
+		serviceNamespace:  serviceNamespace,
+		serviceName:       serviceName,
+		servicePort:       config.WebhookServicePort,
+		namespaceSelector: namespaceSelector,
+		failurePolicy:     failurePolicy,
+		timeoutSeconds:    timeoutSeconds,
+	}, nil
+}
+
+func NewWebhookRegistrarFromConfig(config *ultron.Config, kubernetesConfig *rest.Config) (*WebhookRegistrar, error) {
+	clientSet, err := kubernetes.NewForConfig(kubernetesConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWebhookRegistrar(clientSet, config)
+}
+
+func (wr *WebhookRegistrar) Register(ctx context.Context, caBundle []byte) error {
+	if err := wr.registerMutatingWebhookConfiguration(ctx, caBundle); err != nil {
+		return err
+	}
+
+	return wr.registerValidatingWebhookConfiguration(ctx, caBundle)
+}
+
+func (wr *WebhookRegistrar) registerMutatingWebhookConfiguration(ctx context.Context, caBundle []byte) error {
+	sideEffects := admissionregistrationv1.SideEffectClassNone
+	reinvocationPolicy := admissionregistrationv1.NeverReinvocationPolicy
+	webhooks := []admissionregistrationv1.MutatingWebhook{{
+		Name:                    mutatingWebhookName,
+		ClientConfig:            wr.newClientConfig("/mutate", caBundle),
+		Rules:                   newWebhookRules("deployments", "statefulsets", "replicasets"),
+		NamespaceSelector:       wr.namespaceSelector,
+		FailurePolicy:           &wr.failurePolicy,
+		SideEffects:             &sideEffects,
+		TimeoutSeconds:          &wr.timeoutSeconds,
+		AdmissionReviewVersions: []string{"v1"},
+		ReinvocationPolicy:      &reinvocationPolicy,
+	}}
+
+	client := wr.clientSet.AdmissionregistrationV1().MutatingWebhookConfigurations()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configuration, err := client.Get(ctx, wr.name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			_, err = client.Create(ctx, &admissionregistrationv1.MutatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: wr.name},
+				Webhooks:   webhooks,
+			}, metav1.CreateOptions{})
+		} else if err == nil {
+			configuration.Webhooks = webhooks
+			_, err = client.Update(ctx, configuration, metav1.UpdateOptions{})
+		}
+
+		if err != nil && !apierrors.IsConflict(err) {
+			return fmt.Errorf("failed to register mutating webhook configuration %s: %w", wr.name, err)
+		}
+
+		return err
+	})
+}
+
+func (wr *WebhookRegistrar) registerValidatingWebhookConfiguration(ctx context.Context, caBundle []byte) error {
+	// Validation publishes observe events, which are skipped for dry-run requests.
+	sideEffects := admissionregistrationv1.SideEffectClassNoneOnDryRun
+	webhooks := []admissionregistrationv1.ValidatingWebhook{{
+		Name:                    validatingWebhookName,
+		ClientConfig:            wr.newClientConfig("/validate", caBundle),
+		Rules:                   newWebhookRules("deployments", "statefulsets", "daemonsets", "replicasets"),
+		NamespaceSelector:       wr.namespaceSelector,
+		FailurePolicy:           &wr.failurePolicy,
+		SideEffects:             &sideEffects,
+		TimeoutSeconds:          &wr.timeoutSeconds,
+		AdmissionReviewVersions: []string{"v1"},
+	}}
+
+	client := wr.clientSet.AdmissionregistrationV1().ValidatingWebhookConfigurations()
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configuration, err := client.Get(ctx, wr.name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			_, err = client.Create(ctx, &admissionregistrationv1.ValidatingWebhookConfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: wr.name},
+				Webhooks:   webhooks,
+			}, metav1.CreateOptions{})
+		} else if err == nil {
+			configuration.Webhooks = webhooks
+			_, err = client.Update(ctx, configuration, metav1.UpdateOptions{})
+		}
+
+		if err != nil && !apierrors.IsConflict(err) {
+			return fmt.Errorf("failed to register validating webhook configuration %s: %w", wr.name, err)
+		}
+
+		return err
+	})
+}
+
+func (wr *WebhookRegistrar) newClientConfig(path string, caBundle []byte) admissionregistrationv1.WebhookClientConfig {
+	return admissionregistrationv1.WebhookClientConfig{
+		Service: &admissionregistrationv1.ServiceReference{
+			Namespace: wr.serviceNamespace,
+			Name:      wr.serviceName,
+			Path:      &path,
+			Port:      &wr.servicePort,
+		},
+		CABundle: caBundle,
+	}
+}
+
+// newWebhookRules only admits workload creation, as re-placing templates on every update would roll
+// out workloads whose pods did not change. DaemonSets are only validated, never placed.
+func newWebhookRules(appsResources ...string) []admissionregistrationv1.RuleWithOperations {
+	return []admissionregistrationv1.RuleWithOperations{
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{""},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"pods"},
+			},
+		},
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{"apps"},
+				APIVersions: []string{"v1"},
+				Resources:   appsResources,
+			},
+		},
+		{
+			Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create},
+			Rule: admissionregistrationv1.Rule{
+				APIGroups:   []string{"batch"},
+				APIVersions: []string{"v1"},
+				Resources:   []string{"jobs", "cronjobs"},
+			},
+		},
+	}
+}
@@ -0,0 +1,131 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+	"github.com/stretchr/testify/assert"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newWebhookRegistrarConfig() *ultron.Config {
+	return &ultron.Config{
+		WebhookConfigurationName: "ultron",
+		WebhookService:           "ultron/ultron-service",
+		WebhookServicePort:       443,
+		WebhookNamespaceSelector: "kubernetes.io/metadata.name notin (kube-system)",
+		AdmissionFailurePolicy:   ultron.FailurePolicyClosed,
+		AdmissionTimeout:         5 * time.Second,
+	}
+}
+
+func TestWebhookRegistrar_RegisterCreates(t *testing.T) {
+	// Arrange
+	clientSet := fake.NewSimpleClientset()
+	registrar, _ := services.NewWebhookRegistrar(clientSet, newWebhookRegistrarConfig())
+
+	// Act
+	err := registrar.Register(context.Background(), []byte("ca-bundle"))
+
+	// Assert
+	assert.NoError(t, err)
+
+	mutating, err := clientSet.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.Background(), "ultron", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, mutating.Webhooks, 1)
+	assert.Equal(t, []byte("ca-bundle"), mutating.Webhooks[0].ClientConfig.CABundle)
+	assert.Equal(t, "/mutate", *mutating.Webhooks[0].ClientConfig.Service.Path)
+	assert.Equal(t, "ultron", mutating.Webhooks[0].ClientConfig.Service.Namespace)
+	assert.Equal(t, "ultron-service", mutating.Webhooks[0].ClientConfig.Service.Name)
+	assert.Equal(t, admissionregistrationv1.Fail, *mutating.Webhooks[0].FailurePolicy)
+	assert.Equal(t, int32(5), *mutating.Webhooks[0].TimeoutSeconds)
+	assert.Equal(t, "kubernetes.io/metadata.name", mutating.Webhooks[0].NamespaceSelector.MatchExpressions[0].Key)
+
+	validating, err := clientSet.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(context.Background(), "ultron", metav1.GetOptions{})
+	assert.NoError(t, err)
+	assert.Len(t, validating.Webhooks, 1)
+	assert.Equal(t, []byte("ca-bundle"), validating.Webhooks[0].ClientConfig.CABundle)
+	assert.Equal(t, "/validate", *validating.Webhooks[0].ClientConfig.Service.Path)
+	assert.Equal(t, admissionregistrationv1.SideEffectClassNoneOnDryRun, *validating.Webhooks[0].SideEffects)
+
+	for _, rule := range mutating.Webhooks[0].Rules {
+		assert.Equal(t, []admissionregistrationv1.OperationType{admissionregistrationv1.Create}, rule.Operations, "Expected updates not to be admitted")
+		assert.NotContains(t, rule.Resources, "daemonsets", "Expected DaemonSets not to be placed")
+	}
+
+	assert.Contains(t, validating.Webhooks[0].Rules[1].Resources, "daemonsets", "Expected DaemonSets to be validated")
+}
+
+func TestWebhookRegistrar_RegisterUpdatesCaBundle(t *testing.T) {
+	// Arrange
+	clientSet := fake.NewSimpleClientset(&admissionregistrationv1.MutatingWebhookConfiguration{
+		ObjectMeta: metav1.ObjectMeta{Name: "ultron", Labels: map[string]string{"app": "ultron"}},
+		Webhooks:   []admissionregistrationv1.MutatingWebhook{{Name: "mutate.ultron.io"}},
+	})
+
+	registrar, _ := services.NewWebhookRegistrar(clientSet, newWebhookRegistrarConfig())
+
+	// Act
+	err := registrar.Register(context.Background(), []byte("rotated-ca-bundle"))
+
+	// Assert
+	assert.NoError(t, err)
+
+	mutating, _ := clientSet.AdmissionregistrationV1().MutatingWebhookConfigurations().Get(context.Background(), "ultron", metav1.GetOptions{})
+	assert.Equal(t, []byte("rotated-ca-bundle"), mutating.Webhooks[0].ClientConfig.CABundle)
+	assert.Equal(t, "ultron", mutating.Labels["app"], "Expected existing metadata to be preserved")
+}
+
+func TestWebhookRegistrar_FailOpenAndTimeoutClamp(t *testing.T) {
+	// Arrange
+	clientSet := fake.NewSimpleClientset()
+	config := newWebhookRegistrarConfig()
+	config.AdmissionFailurePolicy = ultron.FailurePolicyOpen
+	config.AdmissionTimeout = time.Minute
+
+	registrar, _ := services.NewWebhookRegistrar(clientSet, config)
+
+	// Act
+	err := registrar.Register(context.Background(), []byte("ca-bundle"))
+
+	// Assert
+	assert.NoError(t, err)
+
+	validating, _ := clientSet.AdmissionregistrationV1().ValidatingWebhookConfigurations().Get(context.Background(), "ultron", metav1.GetOptions{})
+	assert.Equal(t, admissionregistrationv1.Ignore, *validating.Webhooks[0].FailurePolicy)
+	assert.Equal(t, int32(30), *validating.Webhooks[0].TimeoutSeconds, "Expected the timeout to be capped at the API server maximum")
+}
+
+func TestNewWebhookRegistrar_InvalidConfig(t *testing.T) {
+	// Arrange
+	invalidService := newWebhookRegistrarConfig()
+	invalidService.WebhookService = "ultron-service"
+
+	invalidSelector := newWebhookRegistrarConfig()
+	invalidSelector.WebhookNamespaceSelector = "kubernetes.io/metadata.name notin ("
+
+	unsharedAuthority := newWebhookRegistrarConfig()
+	unsharedAuthority.CertificateSource = ultron.CertificateSourceSelfSigned
+
+	sharedAuthority := newWebhookRegistrarConfig()
+	sharedAuthority.CertificateSource = ultron.CertificateSourceSelfSigned
+	sharedAuthority.CertificateAuthorityStore = ultron.CertificateAuthorityStoreSecret
+
+	// Act
+	_, serviceErr := services.NewWebhookRegistrar(fake.NewSimpleClientset(), invalidService)
+	_, selectorErr := services.NewWebhookRegistrar(fake.NewSimpleClientset(), invalidSelector)
+	_, unsharedAuthorityErr := services.NewWebhookRegistrar(fake.NewSimpleClientset(), unsharedAuthority)
+	_, sharedAuthorityErr := services.NewWebhookRegistrar(fake.NewSimpleClientset(), sharedAuthority)
+
+	// Assert
+	assert.Error(t, serviceErr, "Expected an error for a service without a namespace")
+	assert.Error(t, selectorErr, "Expected an error for an invalid namespace selector")
+	assert.Error(t, unsharedAuthorityErr, "Expected an error for self-signed replicas without a shared CA")
+	assert.NoError(t, sharedAuthorityErr)
+}
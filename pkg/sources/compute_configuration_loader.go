@@ -0,0 +1,89 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+)
+
+type IComputeConfigurationLoader interface {
+	Start(ctx context.Context, interval time.Duration) error
+	Load(ctx context.Context) error
+}
+
+type ComputeConfigurationLoader struct {
+	source       IComputeConfigurationSource
+	cacheService services.ICacheService
+}
+
+func NewComputeConfigurationLoader(source IComputeConfigurationSource, cacheService services.ICacheService) *ComputeConfigurationLoader {
+	return &ComputeConfigurationLoader{
+		source:       source,
+		cacheService: cacheService,
+	}
+}
+
+func (l *ComputeConfigurationLoader) Start(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := l.Load(ctx); err != nil {
+			log.Printf("Could not load compute configurations: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *ComputeConfigurationLoader) Load(ctx context.Context) error {
+	computeConfigurations, err := l.source.Load(ctx)
+	if err != nil {
+		return err
+	}
+
+	durableConfigurations := []ultron.ComputeConfiguration{}
+	ephemeralConfigurations := []ultron.ComputeConfiguration{}
+
+	for i, computeConfiguration := range computeConfigurations {
+		if err := ValidateComputeConfiguration(&computeConfiguration); err != nil {
+			log.Printf("Skipping invalid compute configuration %s: %v", computeConfigurationName(i, &computeConfiguration), err)
+
+			continue
+		}
+
+		if computeConfiguration.ComputeType == ultron.ComputeTypeDurable {
+			durableConfigurations = append(durableConfigurations, computeConfiguration)
+		} else {
+			ephemeralConfigurations = append(ephemeralConfigurations, computeConfiguration)
+		}
+	}
+
+	// Keep serving the previous configurations rather than wiping them on a bad reload.
+	if len(durableConfigurations) == 0 && len(ephemeralConfigurations) == 0 {
+		return fmt.Errorf("no valid compute configurations in %d loaded", len(computeConfigurations))
+	}
+
+	if err := l.cacheService.AddCacheItem(ctx, ultron.CacheKeyDurableComputeConfigurations, durableConfigurations, 0); err != nil {
+		return err
+	}
+
+	return l.cacheService.AddCacheItem(ctx, ultron.CacheKeyEphemeralComputeConfigurations, ephemeralConfigurations, 0)
+}
+
+func computeConfigurationName(index int, computeConfiguration *ultron.ComputeConfiguration) string {
+	if computeConfiguration.Identifier != nil {
+		return *computeConfiguration.Identifier
+	}
+
+	return "#" + strconv.Itoa(index)
+}
@@ -0,0 +1,89 @@
+package sources_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	services "github.com/be-heroes/ultron/pkg/services"
+	sources "github.com/be-heroes/ultron/pkg/sources"
+)
+
+type staticSource struct {
+	computeConfigurations []ultron.ComputeConfiguration
+	err                   error
+}
+
+func (s *staticSource) Load(ctx context.Context) ([]ultron.ComputeConfiguration, error) {
+	return s.computeConfigurations, s.err
+}
+
+func TestComputeConfigurationLoaderLoad_Success(t *testing.T) {
+	// Arrange
+	cacheService := services.NewCacheService(nil, nil, 0)
+	invalid := validComputeConfiguration(ultron.ComputeTypeDurable)
+	invalid.VCpu = nil
+
+	loader := sources.NewComputeConfigurationLoader(&staticSource{
+		computeConfigurations: []ultron.ComputeConfiguration{
+			validComputeConfiguration(ultron.ComputeTypeDurable),
+			validComputeConfiguration(ultron.ComputeTypeEphemeral),
+			validComputeConfiguration(ultron.ComputeTypeEphemeral),
+			invalid,
+		},
+	}, cacheService)
+
+	// Act
+	err := loader.Load(context.Background())
+	durableConfigurations, durableErr := cacheService.GetDurableComputeConfigurations(context.Background())
+	ephemeralConfigurations, ephemeralErr := cacheService.GetEphemeralComputeConfigurations(context.Background())
+
+	// Assert
+	assert.NoError(t, err, "Load should not return an error")
+	assert.NoError(t, durableErr)
+	assert.NoError(t, ephemeralErr)
+	assert.Len(t, durableConfigurations, 1, "Expected invalid configurations to be skipped")
+	assert.Len(t, ephemeralConfigurations, 2)
+}
+
+func TestComputeConfigurationLoaderLoad_SourceFailure(t *testing.T) {
+	// Arrange
+	cacheService := services.NewCacheService(nil, nil, 0)
+	loader := sources.NewComputeConfigurationLoader(&staticSource{err: fmt.Errorf("connection refused")}, cacheService)
+
+	// Act
+	err := loader.Load(context.Background())
+	_, cacheErr := cacheService.GetDurableComputeConfigurations(context.Background())
+
+	// Assert
+	assert.Error(t, err, "Expected an error when the source fails")
+	assert.Error(t, cacheErr, "Expected the cache to be left untouched")
+}
+
+func TestComputeConfigurationLoaderLoad_NoValidConfigurationsKeepsCache(t *testing.T) {
+	// Arrange
+	cacheService := services.NewCacheService(nil, nil, 0)
+	invalid := validComputeConfiguration(ultron.ComputeTypeDurable)
+	invalid.VCpu = nil
+
+	_ = sources.NewComputeConfigurationLoader(&staticSource{
+		computeConfigurations: []ultron.ComputeConfiguration{validComputeConfiguration(ultron.ComputeTypeDurable)},
+	}, cacheService).Load(context.Background())
+
+	emptyLoader := sources.NewComputeConfigurationLoader(&staticSource{}, cacheService)
+	invalidLoader := sources.NewComputeConfigurationLoader(&staticSource{computeConfigurations: []ultron.ComputeConfiguration{invalid}}, cacheService)
+
+	// Act
+	emptyErr := emptyLoader.Load(context.Background())
+	invalidErr := invalidLoader.Load(context.Background())
+	durableConfigurations, durableErr := cacheService.GetDurableComputeConfigurations(context.Background())
+
+	// Assert
+	assert.Error(t, emptyErr, "Expected an error for a source without configurations")
+	assert.Error(t, invalidErr, "Expected an error when every configuration is invalid")
+	assert.NoError(t, durableErr)
+	assert.Len(t, durableConfigurations, 1, "Expected the previous configurations to be kept")
+}
@@ -0,0 +1,83 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+type IComputeConfigurationSource interface {
+	Load(ctx context.Context) ([]ultron.ComputeConfiguration, error)
+}
+
+func NewComputeConfigurationSourceFromConfig(config *ultron.Config, kubernetesConfig *rest.Config) (IComputeConfigurationSource, error) {
+	switch config.ComputeConfigurationSource {
+	case "":
+		return nil, nil
+	case ultron.ComputeConfigurationSourceFile:
+		return NewFileSource(config.ComputeConfigurationLocation), nil
+	case ultron.ComputeConfigurationSourceHttp:
+		return NewHttpSource(config.ComputeConfigurationLocation, nil), nil
+	case ultron.ComputeConfigurationSourceConfigMap:
+		namespace, name, found := strings.Cut(config.ComputeConfigurationLocation, "/")
+		if !found || namespace == "" || name == "" {
+			return nil, fmt.Errorf("config map location must be in the format namespace/name: %s", config.ComputeConfigurationLocation)
+		}
+
+		clientSet, err := kubernetes.NewForConfig(kubernetesConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		return NewConfigMapSource(clientSet, namespace, name), nil
+	default:
+		return nil, fmt.Errorf("unsupported compute configuration source: %s", config.ComputeConfigurationSource)
+	}
+}
+
+func ValidateComputeConfiguration(computeConfiguration *ultron.ComputeConfiguration) error {
+	var missing []string
+
+	if computeConfiguration.VCpu == nil {
+		missing = append(missing, "vCpu")
+	}
+
+	if computeConfiguration.RamGb == nil {
+		missing = append(missing, "ramGb")
+	}
+
+	if computeConfiguration.VolumeGb == nil {
+		missing = append(missing, "volumeGb")
+	}
+
+	if computeConfiguration.VolumeType == nil {
+		missing = append(missing, "volumeType")
+	}
+
+	if computeConfiguration.Cost == nil || computeConfiguration.Cost.PricePerUnit == nil {
+		missing = append(missing, "cost.pricePerUnit")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required fields: %s", strings.Join(missing, ", "))
+	}
+
+	if computeConfiguration.ComputeType != ultron.ComputeTypeDurable && computeConfiguration.ComputeType != ultron.ComputeTypeEphemeral {
+		return fmt.Errorf("invalid computeType: %q", computeConfiguration.ComputeType)
+	}
+
+	if *computeConfiguration.VCpu <= 0 || *computeConfiguration.RamGb <= 0 || *computeConfiguration.VolumeGb < 0 {
+		return fmt.Errorf("vCpu and ramGb must be positive and volumeGb must not be negative")
+	}
+
+	if *computeConfiguration.Cost.PricePerUnit < 0 {
+		return fmt.Errorf("cost.pricePerUnit must not be negative")
+	}
+
+	return nil
+}
@@ -0,0 +1,79 @@
+package sources_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	sources "github.com/be-heroes/ultron/pkg/sources"
+)
+
+func int64Ptr(i int64) *int64       { return &i }
+func float64Ptr(f float64) *float64 { return &f }
+func stringPtr(s string) *string    { return &s }
+
+func validComputeConfiguration(computeType ultron.ComputeType) ultron.ComputeConfiguration {
+	return ultron.ComputeConfiguration{
+		Identifier:        stringPtr("config1"),
+		ComputeType:       computeType,
+		CloudNetworkTypes: []string{"isolated"},
+		VCpu:              int64Ptr(2),
+		RamGb:             int64Ptr(4),
+		VolumeGb:          int64Ptr(50),
+		VolumeType:        stringPtr("SSD"),
+		Cost:              &ultron.ComputeCost{PricePerUnit: float64Ptr(0.1)},
+	}
+}
+
+func TestValidateComputeConfiguration_Success(t *testing.T) {
+	// Arrange
+	computeConfiguration := validComputeConfiguration(ultron.ComputeTypeDurable)
+
+	// Act
+	err := sources.ValidateComputeConfiguration(&computeConfiguration)
+
+	// Assert
+	assert.NoError(t, err, "ValidateComputeConfiguration should not return an error")
+}
+
+func TestValidateComputeConfiguration_MissingFields(t *testing.T) {
+	// Arrange
+	computeConfiguration := ultron.ComputeConfiguration{ComputeType: ultron.ComputeTypeDurable, VCpu: int64Ptr(2)}
+
+	// Act
+	err := sources.ValidateComputeConfiguration(&computeConfiguration)
+
+	// Assert
+	assert.EqualError(t, err, "missing required fields: ramGb, volumeGb, volumeType, cost.pricePerUnit")
+}
+
+func TestValidateComputeConfiguration_InvalidValues(t *testing.T) {
+	// Arrange
+	invalidComputeType := validComputeConfiguration("spot")
+	invalidVCpu := validComputeConfiguration(ultron.ComputeTypeDurable)
+	invalidVCpu.VCpu = int64Ptr(0)
+	invalidPrice := validComputeConfiguration(ultron.ComputeTypeEphemeral)
+	invalidPrice.Cost.PricePerUnit = float64Ptr(-1)
+
+	// Act & Assert
+	assert.Error(t, sources.ValidateComputeConfiguration(&invalidComputeType), "Expected an error for an unknown compute type")
+	assert.Error(t, sources.ValidateComputeConfiguration(&invalidVCpu), "Expected an error for a non-positive vCpu")
+	assert.Error(t, sources.ValidateComputeConfiguration(&invalidPrice), "Expected an error for a negative price")
+}
+
+func TestNewComputeConfigurationSourceFromConfig(t *testing.T) {
+	// Act
+	noSource, noSourceErr := sources.NewComputeConfigurationSourceFromConfig(&ultron.Config{}, nil)
+	fileSource, fileSourceErr := sources.NewComputeConfigurationSourceFromConfig(&ultron.Config{ComputeConfigurationSource: ultron.ComputeConfigurationSourceFile, ComputeConfigurationLocation: "configurations.yaml"}, nil)
+	_, invalidLocationErr := sources.NewComputeConfigurationSourceFromConfig(&ultron.Config{ComputeConfigurationSource: ultron.ComputeConfigurationSourceConfigMap, ComputeConfigurationLocation: "ultron"}, nil)
+	_, unsupportedErr := sources.NewComputeConfigurationSourceFromConfig(&ultron.Config{ComputeConfigurationSource: "ftp"}, nil)
+
+	// Assert
+	assert.NoError(t, noSourceErr)
+	assert.Nil(t, noSource, "Expected no source when none is configured")
+	assert.NoError(t, fileSourceErr)
+	assert.IsType(t, &sources.FileSource{}, fileSource)
+	assert.Error(t, invalidLocationErr, "Expected an error for a config map location without a namespace")
+	assert.Error(t, unsupportedErr, "Expected an error for an unsupported source")
+}
@@ -0,0 +1,54 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+type ConfigMapSource struct {
+	clientSet kubernetes.Interface
+	namespace string
+	name      string
+}
+
+func NewConfigMapSource(clientSet kubernetes.Interface, namespace string, name string) *ConfigMapSource {
+	return &ConfigMapSource{
+		clientSet: clientSet,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+func (cs *ConfigMapSource) Load(ctx context.Context) ([]ultron.ComputeConfiguration, error) {
+	configMap, err := cs.clientSet.CoreV1().ConfigMaps(cs.namespace).Get(ctx, cs.name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config map %s/%s: %w", cs.namespace, cs.name, err)
+	}
+
+	var keys []string
+	for key := range configMap.Data {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var computeConfigurations []ultron.ComputeConfiguration
+
+	for _, key := range keys {
+		var entries []ultron.ComputeConfiguration
+		if err := yaml.Unmarshal([]byte(configMap.Data[key]), &entries); err != nil {
+			return nil, fmt.Errorf("failed to parse compute configurations from config map key %s: %w", key, err)
+		}
+
+		computeConfigurations = append(computeConfigurations, entries...)
+	}
+
+	return computeConfigurations, nil
+}
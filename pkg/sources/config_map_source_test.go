@@ -0,0 +1,47 @@
+package sources_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sources "github.com/be-heroes/ultron/pkg/sources"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestConfigMapSourceLoad_Success(t *testing.T) {
+	// Arrange
+	clientSet := fake.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "ultron-compute-configurations", Namespace: "ultron"},
+		Data: map[string]string{
+			"durable.yaml":   "- identifier: config1\n  computeType: durable\n",
+			"ephemeral.json": `[{"identifier":"config2","computeType":"ephemeral"}]`,
+		},
+	})
+
+	source := sources.NewConfigMapSource(clientSet, "ultron", "ultron-compute-configurations")
+
+	// Act
+	computeConfigurations, err := source.Load(context.Background())
+
+	// Assert
+	assert.NoError(t, err, "Load should not return an error")
+	assert.Len(t, computeConfigurations, 2)
+	assert.Equal(t, "config1", *computeConfigurations[0].Identifier)
+	assert.Equal(t, "config2", *computeConfigurations[1].Identifier)
+}
+
+func TestConfigMapSourceLoad_NotFound(t *testing.T) {
+	// Arrange
+	source := sources.NewConfigMapSource(fake.NewSimpleClientset(), "ultron", "missing")
+
+	// Act
+	_, err := source.Load(context.Background())
+
+	// Assert
+	assert.Error(t, err, "Expected an error when the config map does not exist")
+}
@@ -0,0 +1,35 @@
+package sources
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+
+	"sigs.k8s.io/yaml"
+)
+
+type FileSource struct {
+	path string
+}
+
+func NewFileSource(path string) *FileSource {
+	return &FileSource{
+		path: path,
+	}
+}
+
+func (fs *FileSource) Load(ctx context.Context) ([]ultron.ComputeConfiguration, error) {
+	data, err := os.ReadFile(fs.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read compute configurations from file: %w", err)
+	}
+
+	var computeConfigurations []ultron.ComputeConfiguration
+	if err := yaml.Unmarshal(data, &computeConfigurations); err != nil {
+		return nil, fmt.Errorf("failed to parse compute configurations from file: %w", err)
+	}
+
+	return computeConfigurations, nil
+}
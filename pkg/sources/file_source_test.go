@@ -0,0 +1,70 @@
+package sources_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	sources "github.com/be-heroes/ultron/pkg/sources"
+)
+
+func TestFileSourceLoad_Yaml(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "configurations.yaml")
+	content := `
+- identifier: config1
+  computeType: durable
+  vCpu: 2
+  ramGb: 4
+  volumeGb: 50
+  volumeType: SSD
+  cloudNetworkTypes: [isolated]
+  cost:
+    pricePerUnit: 0.1
+`
+	_ = os.WriteFile(path, []byte(content), 0644)
+
+	source := sources.NewFileSource(path)
+
+	// Act
+	computeConfigurations, err := source.Load(context.Background())
+
+	// Assert
+	assert.NoError(t, err, "Load should not return an error")
+	assert.Len(t, computeConfigurations, 1)
+	assert.Equal(t, "config1", *computeConfigurations[0].Identifier)
+	assert.Equal(t, ultron.ComputeTypeDurable, computeConfigurations[0].ComputeType)
+	assert.Equal(t, 0.1, *computeConfigurations[0].Cost.PricePerUnit)
+}
+
+func TestFileSourceLoad_Json(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "configurations.json")
+	_ = os.WriteFile(path, []byte(`[{"identifier":"config1","computeType":"ephemeral","vCpu":2}]`), 0644)
+
+	source := sources.NewFileSource(path)
+
+	// Act
+	computeConfigurations, err := source.Load(context.Background())
+
+	// Assert
+	assert.NoError(t, err, "Load should not return an error")
+	assert.Len(t, computeConfigurations, 1)
+	assert.Equal(t, ultron.ComputeTypeEphemeral, computeConfigurations[0].ComputeType)
+	assert.Equal(t, int64(2), *computeConfigurations[0].VCpu)
+}
+
+func TestFileSourceLoad_MissingFile(t *testing.T) {
+	// Arrange
+	source := sources.NewFileSource(filepath.Join(t.TempDir(), "missing.yaml"))
+
+	// Act
+	_, err := source.Load(context.Background())
+
+	// Assert
+	assert.Error(t, err, "Expected an error when the file does not exist")
+}
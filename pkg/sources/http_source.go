@@ -0,0 +1,53 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+)
+
+type HttpSource struct {
+	url        string
+	httpClient *http.Client
+}
+
+func NewHttpSource(url string, httpClient *http.Client) *HttpSource {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	return &HttpSource{
+		url:        url,
+		httpClient: httpClient,
+	}
+}
+
+func (hs *HttpSource) Load(ctx context.Context) ([]ultron.ComputeConfiguration, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, hs.url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	response, err := hs.httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch compute configurations: %w", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch compute configurations: unexpected status code %d", response.StatusCode)
+	}
+
+	var computeConfigurations []ultron.ComputeConfiguration
+	if err := json.NewDecoder(response.Body).Decode(&computeConfigurations); err != nil {
+		return nil, fmt.Errorf("failed to parse compute configurations: %w", err)
+	}
+
+	return computeConfigurations, nil
+}
@@ -0,0 +1,47 @@
+package sources_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sources "github.com/be-heroes/ultron/pkg/sources"
+)
+
+func TestHttpSourceLoad_Success(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[{"identifier":"config1","computeType":"durable"},{"identifier":"config2","computeType":"ephemeral"}]`))
+	}))
+	defer server.Close()
+
+	source := sources.NewHttpSource(server.URL, nil)
+
+	// Act
+	computeConfigurations, err := source.Load(context.Background())
+
+	// Assert
+	assert.NoError(t, err, "Load should not return an error")
+	assert.Len(t, computeConfigurations, 2)
+	assert.Equal(t, "config2", *computeConfigurations[1].Identifier)
+}
+
+func TestHttpSourceLoad_UnexpectedStatus(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	source := sources.NewHttpSource(server.URL, nil)
+
+	// Act
+	_, err := source.Load(context.Background())
+
+	// Assert
+	assert.Error(t, err, "Expected an error for a non-200 response")
+}
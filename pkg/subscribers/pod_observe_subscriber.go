@@ -0,0 +1,62 @@
+package subscribers
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	events "github.com/be-heroes/ultron/pkg/events"
+	provisioners "github.com/be-heroes/ultron/pkg/provisioners"
+	services "github.com/be-heroes/ultron/pkg/services"
+)
+
+const ConsumerGroupPodObserve = "ultron-pod-observe"
+
+type IPodObserveSubscriber interface {
+	Start(ctx context.Context) error
+	HandleEvent(ctx context.Context, event *events.ObserveEvent) error
+}
+
+type PodObserveSubscriber struct {
+	eventBus       events.IEventBus
+	computeService services.IComputeService
+	provisioner    provisioners.IProvisioner
+}
+
+func NewPodObserveSubscriber(eventBus events.IEventBus, computeService services.IComputeService, provisioner provisioners.IProvisioner) *PodObserveSubscriber {
+	return &PodObserveSubscriber{
+		eventBus:       eventBus,
+		computeService: computeService,
+		provisioner:    provisioner,
+	}
+}
+
+func (s *PodObserveSubscriber) Start(ctx context.Context) error {
+	return s.eventBus.Subscribe(ctx, ultron.TopicPodObserve, ConsumerGroupPodObserve, s.HandleEvent)
+}
+
+func (s *PodObserveSubscriber) HandleEvent(ctx context.Context, event *events.ObserveEvent) error {
+	// Workloads are observed alongside the pods they create, so only the pods themselves provision.
+	if event.Type != events.EventTypePodObserved || event.Data.Kind != "Pod" {
+		return nil
+	}
+
+	wPod := event.Data.Pod
+	if wPod == nil {
+		return fmt.Errorf("missing weighted pod in event %s", event.Id)
+	}
+
+	computeConfiguration, err := s.computeService.MatchWeightedPodToComputeConfiguration(ctx, wPod)
+	if err != nil {
+		return err
+	}
+
+	if computeConfiguration == nil {
+		log.Printf("No compute configuration matches pod %s", event.Subject)
+
+		return nil
+	}
+
+	return s.provisioner.Provision(ctx, wPod, computeConfiguration)
+}
@@ -0,0 +1,88 @@
+package subscribers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/be-heroes/ultron/mocks"
+	ultron "github.com/be-heroes/ultron/pkg"
+	events "github.com/be-heroes/ultron/pkg/events"
+	subscribers "github.com/be-heroes/ultron/pkg/subscribers"
+)
+
+func TestHandleEvent_Provisions(t *testing.T) {
+	// Arrange
+	mockComputeService := new(mocks.IComputeService)
+	mockProvisioner := new(mocks.IProvisioner)
+
+	subscriber := subscribers.NewPodObserveSubscriber(nil, mockComputeService, mockProvisioner)
+
+	identifier := "t3.medium"
+	computeConfiguration := &ultron.ComputeConfiguration{Identifier: &identifier}
+	event := events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{
+		Kind: "Pod",
+		Name: "pod1",
+		Pod:  &ultron.WeightedPod{Selector: map[string]string{ultron.MetadataName: "pod1"}},
+	})
+
+	mockComputeService.On("MatchWeightedPodToComputeConfiguration", mock.Anything, event.Data.Pod).Return(computeConfiguration, nil)
+	mockProvisioner.On("Provision", mock.Anything, event.Data.Pod, computeConfiguration).Return(nil)
+
+	// Act
+	err := subscriber.HandleEvent(context.Background(), event)
+
+	// Assert
+	assert.NoError(t, err, "HandleEvent should not return an error")
+	mockProvisioner.AssertExpectations(t)
+}
+
+func TestHandleEvent_NoComputeConfiguration(t *testing.T) {
+	// Arrange
+	mockComputeService := new(mocks.IComputeService)
+	mockProvisioner := new(mocks.IProvisioner)
+
+	subscriber := subscribers.NewPodObserveSubscriber(nil, mockComputeService, mockProvisioner)
+
+	event := events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Kind: "Pod", Pod: &ultron.WeightedPod{}})
+
+	mockComputeService.On("MatchWeightedPodToComputeConfiguration", mock.Anything, mock.AnythingOfType("*pkg.WeightedPod")).Return(nil, nil)
+
+	// Act
+	err := subscriber.HandleEvent(context.Background(), event)
+
+	// Assert
+	assert.NoError(t, err, "HandleEvent should not return an error")
+	mockProvisioner.AssertNotCalled(t, "Provision", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandleEvent_MissingPod(t *testing.T) {
+	// Arrange
+	subscriber := subscribers.NewPodObserveSubscriber(nil, new(mocks.IComputeService), new(mocks.IProvisioner))
+
+	// Act
+	err := subscriber.HandleEvent(context.Background(), events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Kind: "Pod"}))
+
+	// Assert
+	assert.Error(t, err, "Expected an error for an event without a weighted pod")
+}
+
+func TestHandleEvent_IgnoresWorkloads(t *testing.T) {
+	// Arrange
+	mockComputeService := new(mocks.IComputeService)
+	mockProvisioner := new(mocks.IProvisioner)
+
+	subscriber := subscribers.NewPodObserveSubscriber(nil, mockComputeService, mockProvisioner)
+
+	event := events.NewObserveEvent(events.EventTypePodObserved, events.ObserveEventData{Kind: "Deployment", Pod: &ultron.WeightedPod{}})
+
+	// Act
+	err := subscriber.HandleEvent(context.Background(), event)
+
+	// Assert
+	assert.NoError(t, err, "HandleEvent should not return an error")
+	mockComputeService.AssertNotCalled(t, "MatchWeightedPodToComputeConfiguration", mock.Anything, mock.Anything)
+	mockProvisioner.AssertNotCalled(t, "Provision", mock.Anything, mock.Anything, mock.Anything)
+}
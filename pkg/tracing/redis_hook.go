@@ -0,0 +1,77 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type RedisHook struct{}
+
+func NewRedisHook() *RedisHook {
+	return &RedisHook{}
+}
+
+func (h *RedisHook) DialHook(next redis.DialHook) redis.DialHook {
+	return func(ctx context.Context, network string, addr string) (net.Conn, error) {
+		ctx, span := StartSpan(ctx, "redis.dial", trace.WithSpanKind(trace.SpanKindClient))
+
+		conn, err := next(ctx, network, addr)
+
+		EndSpan(span, err)
+
+		return conn, err
+	}
+}
+
+func (h *RedisHook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		ctx, span := StartSpan(ctx, "redis."+cmd.Name(), trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBOperationName(cmd.Name()),
+		))
+
+		err := next(ctx, cmd)
+
+		EndSpan(span, redisError(err))
+
+		return err
+	}
+}
+
+func (h *RedisHook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		names := make([]string, 0, len(cmds))
+		for _, cmd := range cmds {
+			names = append(names, cmd.Name())
+		}
+
+		ctx, span := StartSpan(ctx, "redis.pipeline", trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+			semconv.DBSystemRedis,
+			semconv.DBOperationName(strings.Join(names, " ")),
+			attribute.Int("db.redis.num_cmd", len(cmds)),
+		))
+
+		err := next(ctx, cmds)
+
+		EndSpan(span, redisError(err))
+
+		return err
+	}
+}
+
+// redisError drops redis.Nil, which signals a missing key rather than a failed command.
+func redisError(err error) error {
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,52 @@
+package tracing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+
+	tracing "github.com/be-heroes/ultron/pkg/tracing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRedisHook_RecordsCommandSpans(t *testing.T) {
+	// Arrange
+	exporter := tracetest.NewInMemoryExporter()
+	tracerProvider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previousTracerProvider := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracerProvider)
+
+	t.Cleanup(func() { otel.SetTracerProvider(previousTracerProvider) })
+
+	server := miniredis.RunT(t)
+	redisClient := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	redisClient.AddHook(tracing.NewRedisHook())
+
+	t.Cleanup(func() { redisClient.Close() })
+
+	ctx := context.Background()
+
+	// Act
+	setErr := redisClient.Set(ctx, "key", "value", 0).Err()
+	getErr := redisClient.Get(ctx, "missing").Err()
+
+	// Assert
+	assert.NoError(t, setErr, "Set should not return an error")
+	assert.ErrorIs(t, getErr, redis.Nil)
+
+	spans := map[string]tracetest.SpanStub{}
+	for _, span := range exporter.GetSpans() {
+		spans[span.Name] = span
+	}
+
+	assert.Contains(t, spans, "redis.set", "Expected a span for the set command")
+	assert.Contains(t, spans, "redis.get", "Expected a span for the get command")
+	assert.NotEqual(t, codes.Error, spans["redis.get"].Status.Code, "Expected a missing key not to mark the span as failed")
+}
@@ -0,0 +1,45 @@
+package tracing_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	ultron "github.com/be-heroes/ultron/pkg"
+	tracing "github.com/be-heroes/ultron/pkg/tracing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNewTracerProvider_WithoutEndpoint(t *testing.T) {
+	// Arrange
+	config := &ultron.Config{}
+
+	// Act
+	shutdown, err := tracing.NewTracerProvider(context.Background(), config)
+
+	// Assert
+	assert.NoError(t, err, "NewTracerProvider should not return an error")
+	assert.NoError(t, shutdown(context.Background()), "Shutdown should not return an error")
+}
+
+func TestExtractHttpContext(t *testing.T) {
+	// Arrange
+	_, _ = tracing.NewTracerProvider(context.Background(), &ultron.Config{})
+
+	req := httptest.NewRequest(http.MethodPost, "/mutate", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	// Act
+	ctx := tracing.ExtractHttpContext(req)
+
+	// Assert
+	spanContext := trace.SpanContextFromContext(ctx)
+
+	assert.True(t, spanContext.IsRemote(), "Expected a remote span context")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spanContext.TraceID().String())
+	assert.Equal(t, "00f067aa0ba902b7", spanContext.SpanID().String())
+}
@@ -1,10 +1,18 @@
 package pkg
 
 import (
+	"time"
+
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+type ClusterEventType string
 type ComputeType string
+type FailurePolicy string
+type KeyAlgorithm string
+type PlacementMode string
+type SelectorPrecedence string
 type WorkloadPriorityEnum bool
 
 func (p WorkloadPriorityEnum) String() string {
@@ -15,6 +23,28 @@ func (p WorkloadPriorityEnum) String() string {
 	return WorkloadPriorityLowLabel
 }
 
+type CandidateExplanation struct {
+	Rank        int               `json:"rank"`
+	Selector    map[string]string `json:"selector"`
+	Eligible    bool              `json:"eligible"`
+	Reason      string            `json:"reason,omitempty"`
+	Explanation ScoreExplanation  `json:"explanation"`
+}
+
+type ClusterEvent struct {
+	Type      ClusterEventType
+	Kind      string
+	Object    runtime.Object
+	OldObject runtime.Object
+}
+
+type ConstraintViolation struct {
+	Annotation string `json:"annotation,omitempty"`
+	Value      string `json:"value,omitempty"`
+	Malformed  bool   `json:"malformed"`
+	Reason     string `json:"reason"`
+}
+
 type ComputeConfiguration struct {
 	Identifier        *string      `json:"identifier,omitempty"`
 	Provider          *string      `json:"provider,omitempty"`
@@ -39,15 +69,115 @@ type ComputeCost struct {
 }
 
 type Config struct {
-	RedisServerAddress        string
-	RedisServerPassword       string
-	RedisServerDatabase       int
-	ServerAddress             string
-	CertificateOrganization   string
-	CertificateCommonName     string
-	CertificateDnsNamesCSV    string
-	CertificateIpAddressesCSV string
-	CertificateExportPath     string
+	RedisServerAddress                 string
+	RedisServerPassword                string
+	RedisServerDatabase                int
+	ServerAddress                      string
+	CertificateOrganization            string
+	CertificateCommonName              string
+	CertificateDnsNamesCSV             string
+	CertificateIpAddressesCSV          string
+	CertificateExportPath              string
+	CertificateSource                  string
+	CertificateLocation                string
+	CertificateRenewBefore             time.Duration
+	CertificateValidity                time.Duration
+	CertificateKeyAlgorithm            KeyAlgorithm
+	CertificateAuthorityStore          string
+	CertificateAuthorityLocation       string
+	CertificateAuthorityValidity       time.Duration
+	ClientCaSource                     string
+	ClientCaLocation                   string
+	ClientCaReloadInterval             time.Duration
+	ClientAllowedCommonNamesCSV        string
+	KubernetesConfigPath               string
+	KubernetesMasterUrl                string
+	LocalCacheTtl                      time.Duration
+	NodeObserverInterval               time.Duration
+	InformerResyncPeriod               time.Duration
+	MetricsPollInterval                time.Duration
+	ComputeConfigurationSource         string
+	ComputeConfigurationLocation       string
+	ComputeConfigurationReloadInterval time.Duration
+	WeightProfile                      string
+	WeightProfilesPath                 string
+	WeightProfilesReloadInterval       time.Duration
+	MutationDryRun                     bool
+	MutationPlacementMode              PlacementMode
+	MutationSelectorPrecedence         SelectorPrecedence
+	MutationPreferredCandidates        int
+	AdmissionTimeout                   time.Duration
+	AdmissionFailurePolicy             FailurePolicy
+	ValidationEnforce                  bool
+	EventBusMaxLen                     int64
+	ReadinessMaxCacheAge               time.Duration
+	ShutdownTimeout                    time.Duration
+	TracingEndpoint                    string
+	TracingInsecure                    bool
+	TracingSampleRatio                 float64
+	WebhookRegistrationEnabled         bool
+	WebhookConfigurationName           string
+	WebhookService                     string
+	WebhookServicePort                 int32
+	WebhookNamespaceSelector           string
+	KarpenterEnabled                   bool
+	KarpenterNodeClassGroup            string
+	KarpenterNodeClassKind             string
+	KarpenterNodeClassName             string
+}
+
+func (f FailurePolicy) IsValid() bool {
+	switch f {
+	case FailurePolicyClosed, FailurePolicyOpen:
+		return true
+	}
+
+	return false
+}
+
+func (a KeyAlgorithm) IsValid() bool {
+	switch a {
+	case KeyAlgorithmEcdsa, KeyAlgorithmRsa:
+		return true
+	}
+
+	return false
+}
+
+func (m PlacementMode) IsValid() bool {
+	switch m {
+	case PlacementModeNodeSelector, PlacementModePreferredAffinity, PlacementModeRequiredAffinity:
+		return true
+	}
+
+	return false
+}
+
+func (p SelectorPrecedence) IsValid() bool {
+	switch p {
+	case SelectorPrecedenceSkip, SelectorPrecedenceUltron, SelectorPrecedenceWorkload:
+		return true
+	}
+
+	return false
+}
+
+type RankedWeightedNode struct {
+	Node       WeightedNode
+	TotalScore float64
+}
+
+type ScoreComponent struct {
+	Name         string                 `json:"name"`
+	Weight       float64                `json:"weight"`
+	Score        float64                `json:"score"`
+	Contribution float64                `json:"contribution"`
+	Inputs       map[string]interface{} `json:"inputs"`
+}
+
+type ScoreExplanation struct {
+	Components []ScoreComponent `json:"components"`
+	TotalScore float64          `json:"totalScore"`
 }
 
 type WeightedNode struct {